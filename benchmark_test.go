@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpointer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+const benchmarkDocument = `{
+  "store": {
+    "books": [
+      {"title": "a", "price": 8.95, "tags": ["x", "y"]},
+      {"title": "b", "price": 12.99, "tags": ["z"]}
+    ],
+    "owner": {"name": "c", "address": {"city": "d"}}
+  }
+}`
+
+func benchmarkJSONDocument(tb testing.TB) any {
+	tb.Helper()
+
+	var doc any
+	require.NoError(tb, json.Unmarshal([]byte(benchmarkDocument), &doc))
+
+	return doc
+}
+
+func TestJSONDocument_ZeroAllocs(t *testing.T) {
+	// not parallel: testing.AllocsPerRun must not run concurrently with other tests
+
+	doc := benchmarkJSONDocument(t)
+	get, err := New("/store/books/1/tags/0")
+	require.NoError(t, err)
+	set, err := New("/store/owner/address/city")
+	require.NoError(t, err)
+	setIndex, err := New("/store/books/0/tags/1")
+	require.NoError(t, err)
+	value := any("e")
+
+	t.Run("Get should not allocate", func(t *testing.T) {
+		allocs := testing.AllocsPerRun(100, func() {
+			_, _, _ = get.Get(doc)
+		})
+		assert.EqualT(t, float64(0), allocs)
+	})
+
+	t.Run("Set on an object member should not allocate", func(t *testing.T) {
+		allocs := testing.AllocsPerRun(100, func() {
+			_, _ = set.Set(doc, value)
+		})
+		assert.EqualT(t, float64(0), allocs)
+	})
+
+	t.Run("Set on an array element should not allocate", func(t *testing.T) {
+		allocs := testing.AllocsPerRun(100, func() {
+			_, _ = setIndex.Set(doc, value)
+		})
+		assert.EqualT(t, float64(0), allocs)
+	})
+
+	t.Run("Get through pointers should not allocate", func(t *testing.T) {
+		m := map[string]any{"a": &[]any{1, 2}}
+		p, err := New("/a/1")
+		require.NoError(t, err)
+
+		allocs := testing.AllocsPerRun(100, func() {
+			_, _, _ = p.Get(&m)
+		})
+		assert.EqualT(t, float64(0), allocs)
+	})
+}
+
+func BenchmarkPointer_Get(b *testing.B) {
+	b.Run("with JSON document", func(b *testing.B) {
+		doc := benchmarkJSONDocument(b)
+		p, err := New("/store/books/1/tags/0")
+		require.NoError(b, err)
+		b.ReportAllocs()
+
+		for b.Loop() {
+			_, _, _ = p.Get(doc)
+		}
+	})
+
+	b.Run("with struct document", func(b *testing.B) {
+		doc := testStructJSONDoc(b)
+		p, err := New("/obj/d/1/f/0")
+		require.NoError(b, err)
+		b.ReportAllocs()
+
+		for b.Loop() {
+			_, _, _ = p.Get(doc)
+		}
+	})
+}
+
+func BenchmarkPointer_Set(b *testing.B) {
+	b.Run("with JSON document", func(b *testing.B) {
+		doc := benchmarkJSONDocument(b)
+		p, err := New("/store/owner/address/city")
+		require.NoError(b, err)
+		value := any("e")
+		b.ReportAllocs()
+
+		for b.Loop() {
+			_, _ = p.Set(doc, value)
+		}
+	})
+
+	b.Run("with append to a JSON array", func(b *testing.B) {
+		arr := make([]any, 0, 1)
+		doc := map[string]any{"arr": &arr}
+		p, err := New("/arr/-")
+		require.NoError(b, err)
+		value := any("e")
+		b.ReportAllocs()
+
+		for b.Loop() {
+			arr = arr[:0]
+			_, _ = p.Set(doc, value)
+		}
+	})
+}
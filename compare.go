@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpointer
+
+import (
+	"slices"
+	"strings"
+)
+
+// Equal tells if p and q reference the same location.
+//
+// Tokens are compared in their decoded (unescaped) form.
+func (p Pointer) Equal(q Pointer) bool {
+	return len(p.referenceTokens) == len(q.referenceTokens) && p.HasPrefix(q)
+}
+
+// HasPrefix tells if the tokens of prefix are the leading tokens of p.
+//
+// A pointer has itself as prefix, and every pointer has the empty pointer as prefix.
+func (p Pointer) HasPrefix(prefix Pointer) bool {
+	if len(prefix.referenceTokens) > len(p.referenceTokens) {
+		return false
+	}
+
+	for i, token := range prefix.referenceTokens {
+		if !tokenEqual(token, p.referenceTokens[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsAncestorOf tells if p references a location that contains the location referenced by q, i.e.
+// p is a strict prefix of q.
+func (p Pointer) IsAncestorOf(q Pointer) bool {
+	return len(p.referenceTokens) < len(q.referenceTokens) && q.HasPrefix(p)
+}
+
+// Compare returns an integer comparing two pointers token by token.
+//
+// The result is 0 if p and q are equal, -1 if p sorts before q and +1 otherwise.
+//
+// Tokens are compared in their decoded (unescaped) form:
+//
+//   - tokens that are both array indices are compared numerically (e.g. "9" sorts before "10")
+//   - an array index sorts before any other token
+//   - other tokens are compared lexicographically
+//
+// When a pointer is a prefix of the other, the shorter pointer sorts first: ancestors sort before
+// their descendants.
+//
+// Compare may be used with [slices.SortFunc].
+func (p Pointer) Compare(q Pointer) int {
+	n := min(len(p.referenceTokens), len(q.referenceTokens))
+
+	for i := range n {
+		if c := compareTokens(p.referenceTokens[i], q.referenceTokens[i]); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case len(p.referenceTokens) < len(q.referenceTokens):
+		return -1
+	case len(p.referenceTokens) > len(q.referenceTokens):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// CommonAncestor returns the longest pointer which is a prefix of all the given pointers.
+//
+// It returns the empty pointer if no pointer is given, or if pointers have no common token.
+func CommonAncestor(pointers ...Pointer) Pointer {
+	if len(pointers) == 0 {
+		return Pointer{}
+	}
+
+	common := len(pointers[0].referenceTokens)
+	for _, p := range pointers[1:] {
+		common = min(common, commonPrefixLen(pointers[0], p))
+	}
+
+	return pointers[0].truncate(common)
+}
+
+// RelativeTo returns the relative pointer that references the location of p, when evaluated from
+// the location of base.
+//
+// The relative pointer walks up from base to the common ancestor of p and base, then down to p.
+func (p Pointer) RelativeTo(base Pointer) RelativePointer {
+	common := commonPrefixLen(p, base)
+
+	return RelativePointer{
+		up: len(base.referenceTokens) - common,
+		pointer: Pointer{
+			referenceTokens: slices.Clone(p.referenceTokens[common:]),
+		},
+	}
+}
+
+// truncate returns a new pointer with the first n tokens of p.
+func (p Pointer) truncate(n int) Pointer {
+	if n == 0 {
+		return Pointer{strict: p.strict}
+	}
+
+	referenceTokens := make([]string, n)
+	copy(referenceTokens, p.referenceTokens)
+
+	return Pointer{referenceTokens: referenceTokens, strict: p.strict}
+}
+
+func commonPrefixLen(p, q Pointer) int {
+	n := min(len(p.referenceTokens), len(q.referenceTokens))
+
+	for i := range n {
+		if !tokenEqual(p.referenceTokens[i], q.referenceTokens[i]) {
+			return i
+		}
+	}
+
+	return n
+}
+
+// tokenEqual compares two escaped tokens in their decoded form.
+func tokenEqual(a, b string) bool {
+	return a == b || Unescape(a) == Unescape(b)
+}
+
+// compareTokens compares two escaped tokens in their decoded form, with array indices sorting
+// numerically and before any other token.
+func compareTokens(a, b string) int {
+	if a == b {
+		return 0
+	}
+
+	da, db := Unescape(a), Unescape(b)
+	ia, ib := isArrayIndex(da), isArrayIndex(db)
+
+	switch {
+	case ia && ib:
+		// canonical array indices have no leading zeros: the longer number is the larger one
+		if len(da) != len(db) {
+			if len(da) < len(db) {
+				return -1
+			}
+
+			return 1
+		}
+
+		return strings.Compare(da, db)
+	case ia:
+		return -1
+	case ib:
+		return 1
+	default:
+		return strings.Compare(da, db)
+	}
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpointer
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func mustNew(t *testing.T, jsonPointerString string) Pointer {
+	t.Helper()
+
+	p, err := New(jsonPointerString)
+	require.NoError(t, err)
+
+	return p
+}
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+
+	assert.TrueT(t, mustNew(t, "/a/b").Equal(mustNew(t, "/a/b")))
+	assert.TrueT(t, mustNew(t, "").Equal(FromTokens()))
+	assert.TrueT(t, mustNew(t, "/a~1b").Equal(FromTokens("a/b")))
+	assert.TrueT(t, mustNew(t, "/~2").Equal(mustNew(t, "/~02")), "non-canonical escapes should compare decoded")
+	assert.FalseT(t, mustNew(t, "/a~1b").Equal(mustNew(t, "/a/b")))
+	assert.FalseT(t, mustNew(t, "/a").Equal(mustNew(t, "/a/b")))
+}
+
+func TestHasPrefix(t *testing.T) {
+	t.Parallel()
+
+	p := mustNew(t, "/definitions/a~1b/properties")
+
+	assert.TrueT(t, p.HasPrefix(mustNew(t, "")))
+	assert.TrueT(t, p.HasPrefix(mustNew(t, "/definitions")))
+	assert.TrueT(t, p.HasPrefix(FromTokens("definitions", "a/b")))
+	assert.TrueT(t, p.HasPrefix(p))
+	assert.FalseT(t, p.HasPrefix(mustNew(t, "/definitions/a")))
+	assert.FalseT(t, p.HasPrefix(mustNew(t, "/definitions/a~1b/properties/x")))
+}
+
+func TestIsAncestorOf(t *testing.T) {
+	t.Parallel()
+
+	p := mustNew(t, "/definitions/a~1b")
+
+	assert.TrueT(t, mustNew(t, "").IsAncestorOf(p))
+	assert.TrueT(t, mustNew(t, "/definitions").IsAncestorOf(p))
+	assert.FalseT(t, p.IsAncestorOf(p))
+	assert.FalseT(t, mustNew(t, "/definitions/a").IsAncestorOf(p))
+	assert.FalseT(t, p.IsAncestorOf(mustNew(t, "/definitions")))
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should sort pointers", func(t *testing.T) {
+		input := []string{"/b", "/a/10", "/a/9", "/a/x", "/a", "", "/a/9/z", "/a/~1", "/a/01", "/a/0"}
+		expected := []string{"", "/a", "/a/0", "/a/9", "/a/9/z", "/a/10", "/a/~1", "/a/01", "/a/x", "/b"}
+
+		pointers := make([]Pointer, 0, len(input))
+		for _, s := range input {
+			pointers = append(pointers, mustNew(t, s))
+		}
+
+		slices.SortFunc(pointers, Pointer.Compare)
+
+		actual := make([]string, 0, len(pointers))
+		for _, p := range pointers {
+			actual = append(actual, p.String())
+		}
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("should compare equal pointers", func(t *testing.T) {
+		assert.EqualT(t, 0, mustNew(t, "/a~1b").Compare(FromTokens("a/b")))
+		assert.EqualT(t, 0, FromTokens().Compare(FromTokens()))
+	})
+
+	t.Run("should be antisymmetric", func(t *testing.T) {
+		p, q := mustNew(t, "/a/2"), mustNew(t, "/a/10")
+		assert.EqualT(t, -1, p.Compare(q))
+		assert.EqualT(t, 1, q.Compare(p))
+	})
+}
+
+func TestCommonAncestor(t *testing.T) {
+	t.Parallel()
+
+	assert.TrueT(t, CommonAncestor().IsEmpty())
+	assert.EqualT(t, "/a/b", CommonAncestor(mustNew(t, "/a/b")).String())
+	assert.EqualT(t, "/a", CommonAncestor(mustNew(t, "/a/b/c"), mustNew(t, "/a/x"), mustNew(t, "/a/b")).String())
+	assert.EqualT(t, "/a~1b", CommonAncestor(mustNew(t, "/a~1b/c"), FromTokens("a/b", "d")).String())
+	assert.TrueT(t, CommonAncestor(mustNew(t, "/a"), mustNew(t, "/b")).IsEmpty())
+	assert.EqualT(t, "/a", CommonAncestor(mustNew(t, "/a/b"), mustNew(t, "/a")).String())
+}
+
+func TestRelativeTo(t *testing.T) {
+	t.Parallel()
+
+	var doc any
+	require.NoError(t, json.Unmarshal([]byte(relativeTestDocument), &doc))
+
+	cases := []struct {
+		target   string
+		base     string
+		expected string
+	}{
+		{target: "/foo/1", base: "/foo/1", expected: "0"},
+		{target: "/foo/0", base: "/foo/1", expected: "1/0"},
+		{target: "/highly/nested/objects", base: "/foo/1", expected: "2/highly/nested/objects"},
+		{target: "/highly/nested/objects", base: "/highly", expected: "0/nested/objects"},
+		{target: "", base: "/highly/nested", expected: "2"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.target+" from "+tt.base, func(t *testing.T) {
+			target, base := mustNew(t, tt.target), mustNew(t, tt.base)
+
+			r := target.RelativeTo(base)
+			assert.EqualT(t, tt.expected, r.String())
+
+			expected, _, err := target.Get(doc)
+			require.NoError(t, err)
+
+			actual, _, err := r.Evaluate(doc, base)
+			require.NoError(t, err)
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
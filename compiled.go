@@ -0,0 +1,174 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpointer
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Compiled is a json [Pointer] prepared for repeated use against documents of the same shape.
+//
+// It keeps the decoded tokens of the pointer, and memoizes per struct type the field index path
+// resolved for each token. Once warmed up, resolving a struct field no longer involves the
+// [NameProvider] nor any string manipulation.
+//
+// The options passed to [Compile] or [Pointer.Compile] are bound to the compiled pointer. In
+// particular, the [NameProvider] is captured at compile time: a later call to
+// [SetDefaultNameProvider] does not affect existing compiled pointers.
+//
+// A Compiled pointer may be safely shared between goroutines.
+type Compiled struct {
+	pointer Pointer
+	decoded []string
+	o       options
+}
+
+// Compile parses a json pointer like [New] and prepares it for repeated use.
+func Compile(jsonPointerString string, opts ...Option) (*Compiled, error) {
+	p, err := New(jsonPointerString)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.Compile(opts...), nil
+}
+
+// Compile prepares the pointer for repeated use.
+//
+// See [Compiled].
+func (p Pointer) Compile(opts ...Option) *Compiled {
+	o := optionsWithDefaults(opts)
+	if p.strict {
+		o.strict = true
+	}
+	if o.provider == nil {
+		o.provider = defaultOptions.provider
+	}
+	o.fields = &fieldCache{}
+
+	return &Compiled{
+		pointer: p,
+		decoded: p.DecodedTokens(),
+		o:       o,
+	}
+}
+
+// Pointer returns the json pointer that was compiled.
+func (c *Compiled) Pointer() Pointer {
+	return c.pointer
+}
+
+// String representation of the compiled pointer.
+func (c *Compiled) String() string {
+	return c.pointer.String()
+}
+
+// Get uses the compiled pointer to retrieve a value from a JSON document, like [Pointer.Get].
+func (c *Compiled) Get(document any) (any, reflect.Kind, error) {
+	// full document when empty
+	if len(c.decoded) == 0 {
+		return document, reflect.Invalid, nil
+	}
+
+	node := document
+	for i, decodedToken := range c.decoded {
+		r, knd, err := getSingleImpl(node, decodedToken, c.o)
+		if err != nil {
+			return nil, knd, c.pointer.errAt(opGet, i, nodeKind(node), err)
+		}
+		node = r
+	}
+
+	return node, reflect.ValueOf(node).Kind(), nil
+}
+
+// Set uses the compiled pointer to set a value in a JSON document, like [Pointer.Set].
+//
+// See [Pointer.Set] for the mutation contract.
+func (c *Compiled) Set(document, value any) (any, error) {
+	if err := checkMutable(document); err != nil {
+		return document, c.pointer.errOn(opSet, nodeKind(document), err)
+	}
+
+	// full document when empty
+	if len(c.decoded) == 0 {
+		return document, nil
+	}
+
+	return c.pointer.setAt(document, c.decoded, value, c.o)
+}
+
+// Delete uses the compiled pointer to remove a value from a JSON document, like [Pointer.Delete].
+//
+// See [Pointer.Delete] for the mutation contract.
+func (c *Compiled) Delete(document any) (any, error) {
+	if err := checkMutable(document); err != nil {
+		return document, c.pointer.errOn(opDelete, nodeKind(document), err)
+	}
+
+	if len(c.decoded) == 0 {
+		return document, c.pointer.errOn(opDelete, nodeKind(document), errRemoveRoot())
+	}
+
+	o := c.o
+	o.createMissing = false
+
+	return c.pointer.mutateAt(document, c.decoded, opDelete, o, func(parent any, decodedToken string) (any, error) {
+		return removeSingleImpl(parent, decodedToken, o)
+	})
+}
+
+// fieldCache memoizes the resolution of JSON names into struct field index paths, per struct type.
+//
+// A nil fieldCache resolves fields without memoization.
+type fieldCache struct {
+	plans sync.Map // fieldKey -> fieldPlan
+}
+
+type fieldKey struct {
+	tpe  reflect.Type
+	name string
+}
+
+// fieldPlan is the resolved access path of a struct field. A nil index means that the struct type
+// has no field for this JSON name.
+type fieldPlan struct {
+	name  string
+	index []int
+}
+
+func (c *fieldCache) lookup(tpe reflect.Type, decodedToken string, provider NameProvider) (fieldPlan, bool) {
+	if c == nil {
+		plan := resolveFieldPlan(tpe, decodedToken, provider)
+
+		return plan, plan.index != nil
+	}
+
+	key := fieldKey{tpe: tpe, name: decodedToken}
+	if cached, ok := c.plans.Load(key); ok {
+		plan, _ := cached.(fieldPlan)
+
+		return plan, plan.index != nil
+	}
+
+	plan := resolveFieldPlan(tpe, decodedToken, provider)
+	c.plans.Store(key, plan)
+
+	return plan, plan.index != nil
+}
+
+func resolveFieldPlan(tpe reflect.Type, decodedToken string, provider NameProvider) fieldPlan {
+	nm, ok := provider.GetGoNameForType(tpe, decodedToken)
+	if !ok {
+		return fieldPlan{}
+	}
+
+	sf, ok := tpe.FieldByName(nm)
+	if !ok {
+		return fieldPlan{}
+	}
+
+	return fieldPlan{name: nm, index: sf.Index}
+}
@@ -0,0 +1,204 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpointer
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/go-openapi/jsonpointer/jsonname"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+type compiledInner struct {
+	Value int `json:"value"`
+}
+
+type compiledEmbedded struct {
+	Promoted string `json:"promoted"`
+}
+
+type compiledDoc struct {
+	*compiledEmbedded
+
+	Inner  compiledInner   `json:"inner"`
+	Items  []compiledInner `json:"items"`
+	Escape string          `json:"a/b"`
+}
+
+func TestCompiled(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should get like a pointer", func(t *testing.T) {
+		doc := testDocumentJSON(t)
+
+		for _, input := range []string{"", "/foo/0", "/obj/d/1/f/0", "/a~1b", "/m~0n"} {
+			p, err := New(input)
+			require.NoError(t, err)
+
+			c, err := Compile(input)
+			require.NoError(t, err)
+			assert.EqualT(t, input, c.String())
+			assert.True(t, p.Equal(c.Pointer()))
+
+			expected, expectedKind, err := p.Get(doc)
+			require.NoError(t, err)
+
+			value, kind, err := c.Get(doc)
+			require.NoError(t, err)
+			assert.Equal(t, expected, value)
+			assert.EqualT(t, expectedKind, kind)
+		}
+	})
+
+	t.Run("should resolve struct fields repeatedly", func(t *testing.T) {
+		c, err := Compile("/items/1/value")
+		require.NoError(t, err)
+
+		for i := range 3 {
+			doc := compiledDoc{Items: []compiledInner{{Value: 1}, {Value: i}}}
+
+			value, _, err := c.Get(doc)
+			require.NoError(t, err)
+			assert.Equal(t, i, value)
+		}
+	})
+
+	t.Run("should set and delete", func(t *testing.T) {
+		doc := &compiledDoc{Inner: compiledInner{Value: 1}}
+
+		c, err := Compile("/inner/value")
+		require.NoError(t, err)
+
+		_, err = c.Set(doc, 42)
+		require.NoError(t, err)
+		assert.EqualT(t, 42, doc.Inner.Value)
+
+		_, err = c.Delete(doc)
+		require.NoError(t, err)
+		assert.EqualT(t, 0, doc.Inner.Value)
+
+		escaped, err := Compile("/a~1b")
+		require.NoError(t, err)
+
+		_, err = escaped.Set(doc, "x")
+		require.NoError(t, err)
+		assert.EqualT(t, "x", doc.Escape)
+	})
+
+	t.Run("should append with the dash token", func(t *testing.T) {
+		doc := &compiledDoc{Items: []compiledInner{{Value: 1}}}
+
+		c, err := Compile("/items/-")
+		require.NoError(t, err)
+
+		_, err = c.Set(doc, compiledInner{Value: 3})
+		require.NoError(t, err)
+		assert.Equal(t, []compiledInner{{Value: 1}, {Value: 3}}, doc.Items)
+	})
+
+	t.Run("should query the name provider once per type and token", func(t *testing.T) {
+		stub := &stubNameProvider{mapping: map[string]string{"renamed": "Field"}}
+
+		c, err := Compile("/renamed", WithNameProvider(stub))
+		require.NoError(t, err)
+
+		for range 5 {
+			value, _, err := c.Get(optionStruct{Field: "hello"})
+			require.NoError(t, err)
+			assert.Equal(t, "hello", value)
+		}
+
+		stub.mu.Lock()
+		defer stub.mu.Unlock()
+		assert.Equal(t, []string{"renamed"}, stub.forTypes)
+	})
+
+	t.Run("should memoize missing fields", func(t *testing.T) {
+		c, err := Compile("/bogus")
+		require.NoError(t, err)
+
+		for range 2 {
+			_, _, err = c.Get(compiledDoc{})
+			require.Error(t, err)
+			require.ErrorIs(t, err, ErrPointer)
+			require.ErrorContains(t, err, `no field "bogus"`)
+		}
+	})
+
+	t.Run("should report a field promoted from a nil embedded pointer", func(t *testing.T) {
+		c, err := Compile("/promoted", WithNameProvider(jsonname.NewGoNameProvider()))
+		require.NoError(t, err)
+
+		value, _, err := c.Get(compiledDoc{compiledEmbedded: &compiledEmbedded{Promoted: "p"}})
+		require.NoError(t, err)
+		assert.Equal(t, "p", value)
+
+		_, _, err = c.Get(compiledDoc{})
+		require.Error(t, err)
+		require.ErrorIs(t, err, ErrPointer)
+	})
+
+	t.Run("should keep strict mode", func(t *testing.T) {
+		p, err := NewStrict("/01")
+		require.NoError(t, err)
+
+		_, _, err = p.Compile().Get([]int{1, 2})
+		require.ErrorIs(t, err, ErrInvalidIndex)
+	})
+
+	t.Run("should fall back to the default name provider", func(t *testing.T) {
+		c, err := Compile("/inner/value", WithNameProvider(nil))
+		require.NoError(t, err)
+
+		value, _, err := c.Get(compiledDoc{Inner: compiledInner{Value: 3}})
+		require.NoError(t, err)
+		assert.Equal(t, 3, value)
+	})
+
+	t.Run("should reject an invalid pointer", func(t *testing.T) {
+		_, err := Compile("a")
+		require.ErrorIs(t, err, ErrInvalidStart)
+	})
+
+	t.Run("should be safe for concurrent use", func(t *testing.T) {
+		c, err := Compile("/inner/value")
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+		for i := range 8 {
+			wg.Go(func() {
+				value, _, err := c.Get(compiledDoc{Inner: compiledInner{Value: i}})
+				assert.NoError(t, err)
+				assert.Equal(t, i, value)
+			})
+		}
+		wg.Wait()
+	})
+}
+
+func BenchmarkCompiled_Get(b *testing.B) {
+	doc := compiledDoc{Items: []compiledInner{{Value: 1}, {Value: 2}}}
+
+	b.Run("with pointer", func(b *testing.B) {
+		p, err := New("/items/1/value")
+		require.NoError(b, err)
+		b.ReportAllocs()
+
+		for b.Loop() {
+			_, _, _ = p.Get(doc)
+		}
+	})
+
+	b.Run("with compiled pointer", func(b *testing.B) {
+		c, err := Compile("/items/1/value")
+		require.NoError(b, err)
+		b.ReportAllocs()
+
+		for b.Loop() {
+			_, _, _ = c.Get(doc)
+		}
+	})
+}
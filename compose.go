@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpointer
+
+import (
+	"iter"
+	"slices"
+	"strconv"
+)
+
+// FromTokens creates a new json pointer from decoded (unescaped) reference tokens.
+//
+// Tokens are escaped as needed: this is the safe way to build a pointer from arbitrary keys, e.g.
+// keys that contain a "/" or a "~".
+//
+// Calling FromTokens without any token yields the empty pointer, which refers to the whole document.
+func FromTokens(decoded ...string) Pointer {
+	if len(decoded) == 0 {
+		return Pointer{}
+	}
+
+	referenceTokens := make([]string, 0, len(decoded))
+	for _, token := range decoded {
+		referenceTokens = append(referenceTokens, Escape(token))
+	}
+
+	return Pointer{referenceTokens: referenceTokens}
+}
+
+// Append returns a new pointer with the decoded (unescaped) tokens appended to p.
+func (p Pointer) Append(decoded ...string) Pointer {
+	referenceTokens := make([]string, 0, len(p.referenceTokens)+len(decoded))
+	referenceTokens = append(referenceTokens, p.referenceTokens...)
+	for _, token := range decoded {
+		referenceTokens = append(referenceTokens, Escape(token))
+	}
+
+	return Pointer{referenceTokens: referenceTokens, strict: p.strict}
+}
+
+// AppendIndex returns a new pointer with the array index i appended to p.
+func (p Pointer) AppendIndex(i int) Pointer {
+	return p.Append(strconv.Itoa(i))
+}
+
+// Join returns a new pointer with the tokens of other appended to p.
+func (p Pointer) Join(other Pointer) Pointer {
+	return Pointer{
+		referenceTokens: slices.Concat(p.referenceTokens, other.referenceTokens),
+		strict:          p.strict,
+	}
+}
+
+// Parent returns a new pointer to the parent of the location referenced by p.
+//
+// The parent of the empty pointer is the empty pointer.
+func (p Pointer) Parent() Pointer {
+	if len(p.referenceTokens) == 0 {
+		return p
+	}
+
+	return Pointer{
+		referenceTokens: slices.Clone(p.referenceTokens[:len(p.referenceTokens)-1]),
+		strict:          p.strict,
+	}
+}
+
+// Last returns the last decoded (unescaped) token of p.
+//
+// It returns false for the empty pointer, which has no token.
+func (p Pointer) Last() (string, bool) {
+	if len(p.referenceTokens) == 0 {
+		return "", false
+	}
+
+	return Unescape(p.referenceTokens[len(p.referenceTokens)-1]), true
+}
+
+// Len returns the number of reference tokens in p.
+func (p Pointer) Len() int {
+	return len(p.referenceTokens)
+}
+
+// Tokens iterates over the decoded (unescaped) tokens of p.
+func (p Pointer) Tokens() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for _, token := range p.referenceTokens {
+			if !yield(Unescape(token)) {
+				return
+			}
+		}
+	}
+}
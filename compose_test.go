@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpointer
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestFromTokens(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should escape tokens", func(t *testing.T) {
+		p := FromTokens("definitions", "a/b", "m~n")
+		assert.EqualT(t, "/definitions/a~1b/m~0n", p.String())
+		assert.Equal(t, []string{"definitions", "a/b", "m~n"}, p.DecodedTokens())
+	})
+
+	t.Run("without tokens", func(t *testing.T) {
+		p := FromTokens()
+		assert.TrueT(t, p.IsEmpty())
+		assert.Empty(t, p.String())
+	})
+
+	t.Run("with a single empty token", func(t *testing.T) {
+		p := FromTokens("")
+		assert.EqualT(t, "/", p.String())
+
+		v, _, err := p.Get(testDocumentJSON(t))
+		require.NoError(t, err)
+		assert.Equal(t, float64(0), v)
+	})
+}
+
+func TestCompose(t *testing.T) {
+	t.Parallel()
+
+	base := FromTokens("definitions", "pet/v1")
+
+	t.Run("Append", func(t *testing.T) {
+		p := base.Append("properties", "name")
+		assert.EqualT(t, "/definitions/pet~1v1/properties/name", p.String())
+		assert.EqualT(t, "/definitions/pet~1v1", base.String(), "receiver should not be mutated")
+	})
+
+	t.Run("Append should not alias the receiver", func(t *testing.T) {
+		p := base.Append("a")
+		q := base.Append("b")
+		assert.EqualT(t, "/definitions/pet~1v1/a", p.String())
+		assert.EqualT(t, "/definitions/pet~1v1/b", q.String())
+	})
+
+	t.Run("AppendIndex", func(t *testing.T) {
+		p := FromTokens("foo").AppendIndex(1)
+		assert.EqualT(t, "/foo/1", p.String())
+
+		v, _, err := p.Get(testDocumentJSON(t))
+		require.NoError(t, err)
+		assert.Equal(t, "baz", v)
+	})
+
+	t.Run("Join", func(t *testing.T) {
+		other := FromTokens("properties", "a~b")
+		p := base.Join(other)
+		assert.EqualT(t, "/definitions/pet~1v1/properties/a~0b", p.String())
+		assert.EqualT(t, "/properties/a~0b", FromTokens().Join(other).String())
+		assert.EqualT(t, base.String(), base.Join(Pointer{}).String())
+	})
+
+	t.Run("Parent", func(t *testing.T) {
+		p := base.Parent()
+		assert.EqualT(t, "/definitions", p.String())
+		assert.TrueT(t, p.Parent().IsEmpty())
+		assert.TrueT(t, p.Parent().Parent().IsEmpty())
+
+		q := p.Append("x")
+		assert.EqualT(t, "/definitions/pet~1v1", base.String(), "parent should not alias the receiver")
+		assert.EqualT(t, "/definitions/x", q.String())
+	})
+
+	t.Run("Last", func(t *testing.T) {
+		last, ok := base.Last()
+		require.TrueT(t, ok)
+		assert.EqualT(t, "pet/v1", last)
+
+		_, ok = FromTokens().Last()
+		assert.FalseT(t, ok)
+	})
+
+	t.Run("Len", func(t *testing.T) {
+		assert.EqualT(t, 2, base.Len())
+		assert.EqualT(t, 0, FromTokens().Len())
+	})
+
+	t.Run("Tokens", func(t *testing.T) {
+		assert.Equal(t, []string{"definitions", "pet/v1"}, slices.Collect(base.Tokens()))
+
+		for token := range base.Tokens() {
+			assert.EqualT(t, "definitions", token)
+
+			break
+		}
+	})
+
+	t.Run("strict mode is preserved", func(t *testing.T) {
+		strict, err := NewStrict("/foo")
+		require.NoError(t, err)
+
+		_, _, err = strict.Append("01").Get(testDocumentJSON(t))
+		require.ErrorIs(t, err, ErrInvalidIndex)
+
+		_, _, err = strict.Join(FromTokens("01")).Get(testDocumentJSON(t))
+		require.ErrorIs(t, err, ErrInvalidIndex)
+	})
+}
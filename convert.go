@@ -0,0 +1,261 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpointer
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+//nolint:gochecknoglobals // it's okay to declare a reflect.Type as a private global
+var jsonNumberType = reflect.TypeFor[json.Number]()
+
+// assignableValue returns data as a value that may be assigned to the target type.
+//
+// A nil data yields the zero value of a nillable target type.
+//
+// When coercion is enabled (see [WithCoercion]), values that are not directly assignable are
+// converted like by [convertValue], or else by a JSON round trip.
+func assignableValue(data any, target reflect.Type, o options) (reflect.Value, bool) {
+	value := reflect.ValueOf(data)
+	if !value.IsValid() {
+		if isNillableKind(target.Kind()) {
+			return reflect.Zero(target), true
+		}
+
+		return value, false
+	}
+
+	if value.Type().AssignableTo(target) {
+		return value, true
+	}
+
+	if !o.coerce {
+		return value, false
+	}
+
+	if converted, err := convertValue(data, target); err == nil {
+		return converted, true
+	}
+
+	converted, err := convertJSON(data, target)
+	if err != nil {
+		return value, false
+	}
+
+	return converted, true
+}
+
+// convertValue converts a value to the target type.
+//
+// The conversion follows these rules:
+//
+//   - a value assignable to the target type is returned as is
+//   - pointers are dereferenced until a value assignable to the target type is found
+//   - if the target type is a pointer, the converted value is wrapped into a new pointer
+//   - numbers (including [json.Number]) are converted to any numeric type, provided the value is
+//     represented exactly: e.g. 2.0 converts to int 2, but 2.5 or 300 do not convert to an int8.
+//     Like with encoding/json, floating point numbers are rounded to a float32 target within its range
+//   - strings and booleans are converted to named types with the same underlying kind
+//   - a nil value converts to the zero value of a nillable type (pointer, interface, map, slice ...)
+//
+// Any other conversion is reported by an error wrapping [ErrTypeMismatch].
+func convertValue(value any, target reflect.Type) (reflect.Value, error) {
+	rv := reflect.ValueOf(value)
+
+	for {
+		if !rv.IsValid() || (rv.Kind() == reflect.Pointer && rv.IsNil()) {
+			if isNillableKind(target.Kind()) {
+				return reflect.Zero(target), nil
+			}
+
+			return reflect.Value{}, errTypeMismatch(value, target)
+		}
+
+		if rv.Type().AssignableTo(target) {
+			return rv, nil
+		}
+
+		if rv.Kind() != reflect.Pointer {
+			break
+		}
+
+		rv = rv.Elem()
+	}
+
+	if target.Kind() == reflect.Pointer {
+		elem, err := convertValue(rv.Interface(), target.Elem())
+		if err != nil {
+			return reflect.Value{}, errTypeMismatch(value, target)
+		}
+
+		ptr := reflect.New(target.Elem())
+		ptr.Elem().Set(elem)
+
+		return ptr, nil
+	}
+
+	if converted, ok := convertScalar(rv, target); ok {
+		return converted, nil
+	}
+
+	return reflect.Value{}, errTypeMismatch(value, target)
+}
+
+// convertScalar converts numbers, strings and booleans without loss of information.
+func convertScalar(rv reflect.Value, target reflect.Type) (reflect.Value, bool) {
+	if rv.Type() == jsonNumberType && isNumericKind(target.Kind()) {
+		number := rv.String()
+		if i, err := strconv.ParseInt(number, 10, 64); err == nil {
+			rv = reflect.ValueOf(i)
+		} else if f, err := strconv.ParseFloat(number, 64); err == nil {
+			rv = reflect.ValueOf(f)
+		} else {
+			return reflect.Value{}, false
+		}
+	}
+
+	out := reflect.New(target).Elem()
+
+	switch {
+	case isIntKind(rv.Kind()):
+		return out, setInt(out, rv.Int())
+	case isUintKind(rv.Kind()):
+		return out, setUint(out, rv.Uint())
+	case isFloatKind(rv.Kind()):
+		return out, setFloat(out, rv.Float())
+	case rv.Kind() == reflect.String && target.Kind() == reflect.String,
+		rv.Kind() == reflect.Bool && target.Kind() == reflect.Bool:
+		return rv.Convert(target), true
+	default:
+		return reflect.Value{}, false
+	}
+}
+
+func setInt(out reflect.Value, i int64) bool {
+	switch {
+	case isIntKind(out.Kind()):
+		if out.OverflowInt(i) {
+			return false
+		}
+		out.SetInt(i)
+	case isUintKind(out.Kind()):
+		if i < 0 || out.OverflowUint(uint64(i)) {
+			return false
+		}
+		out.SetUint(uint64(i))
+	case isFloatKind(out.Kind()):
+		f := float64(i)
+		if f >= math.MaxInt64 || int64(f) != i || !isExactFloat(out, f) {
+			return false
+		}
+
+		return setFloat(out, f)
+	default:
+		return false
+	}
+
+	return true
+}
+
+func setUint(out reflect.Value, u uint64) bool {
+	switch {
+	case isIntKind(out.Kind()):
+		if u > math.MaxInt64 || out.OverflowInt(int64(u)) {
+			return false
+		}
+		out.SetInt(int64(u))
+	case isUintKind(out.Kind()):
+		if out.OverflowUint(u) {
+			return false
+		}
+		out.SetUint(u)
+	case isFloatKind(out.Kind()):
+		f := float64(u)
+		if f >= math.MaxUint64 || uint64(f) != u || !isExactFloat(out, f) {
+			return false
+		}
+
+		return setFloat(out, f)
+	default:
+		return false
+	}
+
+	return true
+}
+
+func setFloat(out reflect.Value, f float64) bool {
+	switch {
+	case isIntKind(out.Kind()):
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 || out.OverflowInt(int64(f)) {
+			return false
+		}
+		out.SetInt(int64(f))
+	case isUintKind(out.Kind()):
+		if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 || out.OverflowUint(uint64(f)) {
+			return false
+		}
+		out.SetUint(uint64(f))
+	case isFloatKind(out.Kind()):
+		if out.OverflowFloat(f) {
+			return false
+		}
+		out.SetFloat(f)
+	default:
+		return false
+	}
+
+	return true
+}
+
+// isExactFloat tells if an integral value converted to f is represented exactly by the float target.
+func isExactFloat(out reflect.Value, f float64) bool {
+	return out.Kind() != reflect.Float32 || float64(float32(f)) == f
+}
+
+// convertJSON converts a value to the target type by marshaling it to JSON, then unmarshaling it
+// into a new value of the target type.
+//
+// This is typically used to convert a generic JSON value (e.g. a map[string]any) into a struct.
+func convertJSON(value any, target reflect.Type) (reflect.Value, error) {
+	buf, err := json.Marshal(value)
+	if err != nil {
+		return reflect.Value{}, errors.Join(err, errTypeMismatch(value, target))
+	}
+
+	converted := reflect.New(target)
+	if err := json.Unmarshal(buf, converted.Interface()); err != nil {
+		return reflect.Value{}, errors.Join(err, errTypeMismatch(value, target))
+	}
+
+	return converted.Elem(), nil
+}
+
+func isIntKind(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Int64
+}
+
+func isUintKind(kind reflect.Kind) bool {
+	return kind >= reflect.Uint && kind <= reflect.Uintptr
+}
+
+func isFloatKind(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
+
+func isNumericKind(kind reflect.Kind) bool {
+	return isIntKind(kind) || isUintKind(kind) || isFloatKind(kind)
+}
+
+func isNillableKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+		return true
+	default:
+		return false
+	}
+}
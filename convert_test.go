@@ -0,0 +1,211 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpointer
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestConvertValue(t *testing.T) {
+	t.Parallel()
+
+	type named string
+
+	cases := []struct {
+		name     string
+		value    any
+		target   reflect.Type
+		expected any
+	}{
+		{name: "assignable", value: "a", target: reflect.TypeFor[string](), expected: "a"},
+		{name: "to interface", value: 1, target: reflect.TypeFor[any](), expected: 1},
+		{name: "integral float to int", value: 2.0, target: reflect.TypeFor[int](), expected: 2},
+		{name: "int to float", value: 3, target: reflect.TypeFor[float64](), expected: 3.0},
+		{name: "int to uint", value: 3, target: reflect.TypeFor[uint16](), expected: uint16(3)},
+		{name: "uint to int", value: uint(3), target: reflect.TypeFor[int8](), expected: int8(3)},
+		{name: "float64 to float32", value: 0.5, target: reflect.TypeFor[float32](), expected: float32(0.5)},
+		{name: "rounded float64 to float32", value: 0.1, target: reflect.TypeFor[float32](), expected: float32(0.1)},
+		{name: "large int to float", value: int64(1 << 53), target: reflect.TypeFor[float64](), expected: float64(1 << 53)},
+		{name: "large uint to float32", value: uint64(1 << 40), target: reflect.TypeFor[float32](), expected: float32(1 << 40)},
+		{name: "json.Number to int", value: json.Number("42"), target: reflect.TypeFor[int](), expected: 42},
+		{name: "json.Number to float", value: json.Number("4.5"), target: reflect.TypeFor[float64](), expected: 4.5},
+		{name: "json.Number to string", value: json.Number("4.5"), target: reflect.TypeFor[string](), expected: "4.5"},
+		{name: "named string", value: "a", target: reflect.TypeFor[named](), expected: named("a")},
+		{name: "nil to map", value: nil, target: reflect.TypeFor[map[string]any](), expected: map[string]any(nil)},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			converted, err := convertValue(tt.value, tt.target)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, converted.Interface())
+		})
+	}
+
+	t.Run("should wrap into a pointer", func(t *testing.T) {
+		converted, err := convertValue(1.0, reflect.TypeFor[*int]())
+		require.NoError(t, err)
+
+		ptr, ok := converted.Interface().(*int)
+		require.TrueT(t, ok)
+		assert.EqualT(t, 1, *ptr)
+	})
+
+	t.Run("should dereference pointers", func(t *testing.T) {
+		f := 1.0
+		pf := &f
+
+		converted, err := convertValue(&pf, reflect.TypeFor[int]())
+		require.NoError(t, err)
+		assert.Equal(t, 1, converted.Interface())
+	})
+
+	mismatches := []struct {
+		name   string
+		value  any
+		target reflect.Type
+	}{
+		{name: "fractional float to int", value: 2.5, target: reflect.TypeFor[int]()},
+		{name: "int overflow", value: 300, target: reflect.TypeFor[int8]()},
+		{name: "negative to uint", value: -1, target: reflect.TypeFor[uint]()},
+		{name: "large uint to int", value: uint64(math.MaxUint64), target: reflect.TypeFor[int64]()},
+		{name: "float overflow", value: math.MaxFloat64, target: reflect.TypeFor[float32]()},
+		{name: "inexact int to float", value: int64(1<<53 + 1), target: reflect.TypeFor[float64]()},
+		{name: "inexact int to float32", value: 1<<24 + 1, target: reflect.TypeFor[float32]()},
+		{name: "inexact uint to float", value: uint64(math.MaxUint64), target: reflect.TypeFor[float64]()},
+		{name: "max int to float", value: int64(math.MaxInt64), target: reflect.TypeFor[float64]()},
+		{name: "huge float to int", value: 1e300, target: reflect.TypeFor[int64]()},
+		{name: "string to int", value: "1", target: reflect.TypeFor[int]()},
+		{name: "int to string", value: 1, target: reflect.TypeFor[string]()},
+		{name: "nil to int", value: nil, target: reflect.TypeFor[int]()},
+		{name: "nil pointer to int", value: (*int)(nil), target: reflect.TypeFor[int]()},
+		{name: "invalid json.Number", value: json.Number("x"), target: reflect.TypeFor[int]()},
+		{name: "pointer to mismatching type", value: "x", target: reflect.TypeFor[*int]()},
+	}
+
+	for _, tt := range mismatches {
+		t.Run("should not convert "+tt.name, func(t *testing.T) {
+			_, err := convertValue(tt.value, tt.target)
+			require.Error(t, err)
+			require.ErrorIs(t, err, ErrTypeMismatch)
+			require.ErrorIs(t, err, ErrPointer)
+		})
+	}
+}
+
+type coercionAddress struct {
+	City string `json:"city"`
+	Zip  int    `json:"zip"`
+}
+
+type coercionModel struct {
+	Count   int32           `json:"count"`
+	Name    *string         `json:"name"`
+	Address coercionAddress `json:"address"`
+	Scores  []int           `json:"scores"`
+	Limits  map[string]int  `json:"limits"`
+}
+
+func TestWithCoercion(t *testing.T) {
+	t.Parallel()
+
+	newModel := func() *coercionModel {
+		return &coercionModel{Scores: []int{1, 2}, Limits: map[string]int{}}
+	}
+
+	t.Run("should coerce values", func(t *testing.T) {
+		doc := newModel()
+
+		for _, set := range []struct {
+			pointer string
+			value   any
+		}{
+			{pointer: "/count", value: float64(12)},
+			{pointer: "/name", value: "x"},
+			{pointer: "/address", value: map[string]any{"city": "Paris", "zip": float64(75001)}},
+			{pointer: "/scores/0", value: float64(5)},
+			{pointer: "/scores/-", value: json.Number("7")},
+			{pointer: "/limits/max", value: float64(100)},
+		} {
+			p, err := New(set.pointer)
+			require.NoError(t, err)
+
+			_, err = p.Set(doc, set.value, WithCoercion())
+			require.NoError(t, err, "pointer: %q", set.pointer)
+		}
+
+		assert.EqualT(t, int32(12), doc.Count)
+		require.NotNil(t, doc.Name)
+		assert.EqualT(t, "x", *doc.Name)
+		assert.Equal(t, coercionAddress{City: "Paris", Zip: 75001}, doc.Address)
+		assert.Equal(t, []int{5, 2, 7}, doc.Scores)
+		assert.Equal(t, map[string]int{"max": 100}, doc.Limits)
+	})
+
+	t.Run("should apply a JSON patch to a typed model", func(t *testing.T) {
+		doc := newModel()
+		patch, err := DecodePatch([]byte(`[
+			{"op": "replace", "path": "/count", "value": 3},
+			{"op": "add", "path": "/scores/1", "value": 9},
+			{"op": "replace", "path": "/address", "value": {"city": "Lyon"}}
+		]`))
+		require.NoError(t, err)
+
+		_, err = ApplyPatch(doc, patch, WithCoercion())
+		require.NoError(t, err)
+
+		assert.EqualT(t, int32(3), doc.Count)
+		assert.Equal(t, []int{1, 9, 2}, doc.Scores)
+		assert.EqualT(t, "Lyon", doc.Address.City)
+	})
+
+	t.Run("should reject values that do not convert", func(t *testing.T) {
+		for _, set := range []struct {
+			pointer string
+			value   any
+		}{
+			{pointer: "/count", value: 2.5},
+			{pointer: "/count", value: float64(math.MaxInt64)},
+			{pointer: "/scores/0", value: "x"},
+			{pointer: "/address", value: []any{1}},
+			{pointer: "/limits/max", value: true},
+		} {
+			p, err := New(set.pointer)
+			require.NoError(t, err)
+
+			_, err = p.Set(newModel(), set.value, WithCoercion())
+			require.Error(t, err, "pointer: %q", set.pointer)
+			require.ErrorIs(t, err, ErrPointer)
+		}
+	})
+
+	t.Run("should not coerce by default", func(t *testing.T) {
+		p, err := New("/count")
+		require.NoError(t, err)
+
+		_, err = p.Set(newModel(), float64(12))
+		require.Error(t, err)
+		require.ErrorIs(t, err, ErrPointer)
+		require.ErrorContains(t, err, "can't set value with type float64")
+	})
+
+	t.Run("should set nil into a nillable target", func(t *testing.T) {
+		s := "x"
+		doc := newModel()
+		doc.Name = &s
+
+		p, err := New("/name")
+		require.NoError(t, err)
+
+		_, err = p.Set(doc, nil)
+		require.NoError(t, err)
+		assert.Nil(t, doc.Name)
+	})
+}
@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpointer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+type deletableMap map[string]int
+
+func (d deletableMap) JSONDelete(key string) error {
+	if _, ok := d[key]; !ok {
+		return fmt.Errorf("no key %q: %w", key, ErrPointer)
+	}
+
+	delete(d, key)
+
+	return nil
+}
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should delete a map entry", func(t *testing.T) {
+		doc := map[string]any{"a": map[string]any{"b": 1, "c": 2}}
+		p, err := New("/a/b")
+		require.NoError(t, err)
+
+		out, err := p.Delete(doc)
+		require.NoError(t, err)
+		assert.Equal(t, doc, out)
+		assert.Equal(t, map[string]any{"a": map[string]any{"c": 2}}, doc)
+	})
+
+	t.Run("should splice a slice nested in a map", func(t *testing.T) {
+		doc := map[string]any{"arr": []any{1, 2, 3}}
+		p, err := New("/arr/1")
+		require.NoError(t, err)
+
+		_, err = p.Delete(doc)
+		require.NoError(t, err)
+		assert.Equal(t, []any{1, 3}, doc["arr"])
+	})
+
+	t.Run("should splice a slice field in place", func(t *testing.T) {
+		type item struct {
+			V int `json:"v"`
+		}
+		doc := struct {
+			Items []item `json:"items"`
+		}{Items: []item{{V: 1}, {V: 2}, {V: 3}}}
+
+		p, err := New("/items/0")
+		require.NoError(t, err)
+
+		_, err = p.Delete(&doc)
+		require.NoError(t, err)
+		assert.Equal(t, []item{{V: 2}, {V: 3}}, doc.Items)
+	})
+
+	t.Run("should splice a top-level *[]T in place", func(t *testing.T) {
+		doc := []int{1, 2, 3}
+		p, err := New("/2")
+		require.NoError(t, err)
+
+		_, err = p.Delete(&doc)
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2}, doc)
+	})
+
+	t.Run("should return a new slice for a top-level slice passed by value", func(t *testing.T) {
+		doc := []int{1, 2, 3}
+		p, err := New("/0")
+		require.NoError(t, err)
+
+		out, err := p.Delete(doc)
+		require.NoError(t, err)
+		assert.Equal(t, []int{2, 3}, out)
+		assert.Equal(t, []int{1, 2, 3}, doc)
+	})
+
+	t.Run("should zero a struct field", func(t *testing.T) {
+		doc := &testStructJSON{Foo: []string{"a"}}
+		doc.Obj.A = 12
+		p, err := New("/obj/a")
+		require.NoError(t, err)
+
+		_, err = p.Delete(doc)
+		require.NoError(t, err)
+		assert.EqualT(t, 0, doc.Obj.A)
+	})
+
+	t.Run("should delegate to JSONDeletable", func(t *testing.T) {
+		doc := map[string]any{"d": deletableMap{"x": 1, "y": 2}}
+		p, err := New("/d/x")
+		require.NoError(t, err)
+
+		_, err = p.Delete(doc)
+		require.NoError(t, err)
+		assert.Equal(t, deletableMap{"y": 2}, doc["d"])
+	})
+
+	t.Run("with RemoveForToken", func(t *testing.T) {
+		doc := map[string]any{"a": 1, "b": 2}
+
+		_, err := RemoveForToken(doc, "a")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"b": 2}, doc)
+	})
+}
+
+func TestDelete_Errors(t *testing.T) {
+	t.Parallel()
+
+	newDoc := func() map[string]any {
+		return map[string]any{
+			"m":   map[string]any{"k": 1},
+			"arr": []any{1, 2},
+			"n":   nil,
+			"s":   &testStructJSON{},
+			"d":   deletableMap{},
+		}
+	}
+
+	cases := []struct {
+		name    string
+		pointer string
+		substr  string
+	}{
+		{name: "root document", pointer: "", substr: "root document"},
+		{name: "missing map key", pointer: "/m/missing", substr: `no key "missing"`},
+		{name: "missing intermediate key", pointer: "/missing/k", substr: `no key "missing"`},
+		{name: "slice out of bounds", pointer: "/arr/2", substr: "out of bounds"},
+		{name: "slice non-numeric index", pointer: "/arr/x", substr: `parsing "x"`},
+		{name: "dash token", pointer: "/arr/-", substr: `"-"`},
+		{name: "nil value", pointer: "/n/k", substr: "nil value"},
+		{name: "unknown struct field", pointer: "/s/bogus", substr: `no field "bogus"`},
+		{name: "scalar", pointer: "/m/k/x", substr: `invalid token reference "x"`},
+		{name: "JSONDeletable error", pointer: "/d/x", substr: `no key "x"`},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := New(tt.pointer)
+			require.NoError(t, err)
+
+			_, err = p.Delete(newDoc())
+			require.Error(t, err)
+			require.ErrorIs(t, err, ErrPointer)
+			require.ErrorContains(t, err, tt.substr)
+		})
+	}
+
+	t.Run("dash token error wraps ErrDashToken", func(t *testing.T) {
+		_, err := RemoveForToken([]int{1}, "-")
+		require.ErrorIs(t, err, ErrDashToken)
+	})
+
+	t.Run("unsupported document type", func(t *testing.T) {
+		p, err := New("/a")
+		require.NoError(t, err)
+
+		_, err = p.Delete(1)
+		require.ErrorIs(t, err, ErrUnsupportedValueType)
+	})
+}
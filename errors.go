@@ -3,7 +3,10 @@
 
 package jsonpointer
 
-import "fmt"
+import (
+	"fmt"
+	"reflect"
+)
 
 type pointerError string
 
@@ -18,8 +21,19 @@ const (
 	// ErrInvalidStart states that a JSON pointer must start with a separator ("/").
 	ErrInvalidStart pointerError = `JSON pointer must be empty or start with a "` + pointerSeparator + `"`
 
+	// ErrInvalidEscape states that a "~" in a JSON pointer must be followed by "0" or "1".
+	//
+	// This is only checked by pointers created with [NewStrict].
+	ErrInvalidEscape pointerError = `JSON pointer escape character "~" must be followed by "0" or "1"`
+
+	// ErrInvalidIndex states that an array index must be "0" or a decimal number without sign nor
+	// leading zeros.
+	//
+	// This is only checked by pointers created with [NewStrict].
+	ErrInvalidIndex pointerError = "JSON pointer array index must be 0 or a positive decimal number without leading zeros"
+
 	// ErrUnsupportedValueType indicates that a value of the wrong type is being set.
-	ErrUnsupportedValueType pointerError = "only structs, pointers, maps and slices are supported for setting values"
+	ErrUnsupportedValueType pointerError = "only structs, pointers, maps, slices and arrays are supported for setting values"
 
 	// ErrDashToken indicates use of the RFC 6901 "-" reference token in a context where it cannot be
 	// resolved.
@@ -28,23 +42,178 @@ const (
 	// It may only be used as the terminal token of a [Pointer.Set] against a slice, where it means
 	// "append".
 	//
-	// Any other use (get, offset, intermediate traversal, non-slice target) is an error condition that
-	// wraps this sentinel.
+	// Any other use (get, offset, intermediate traversal, non-slice target such as a go array of fixed
+	// size) is an error condition that wraps this sentinel.
 	ErrDashToken pointerError = `the "-" array token cannot be resolved here` //nolint:gosec // G101 false positive: this is a JSON Pointer reference token, not a credential.
+
+	// ErrInvalidPatch indicates a malformed RFC 6902 JSON Patch document or operation.
+	ErrInvalidPatch pointerError = "invalid JSON patch"
+
+	// ErrPatchTest indicates that an RFC 6902 "test" operation did not match the target value.
+	ErrPatchTest pointerError = "JSON patch test operation failed"
+
+	// ErrTypeMismatch indicates that a value cannot be converted to the expected type, or that a
+	// token cannot be resolved against a value of this type, e.g. a token against a string.
+	ErrTypeMismatch pointerError = "JSON pointer value does not match the expected type"
+
+	// ErrNotFound indicates that an object has no such key or struct field, or that a token that is
+	// not a number is resolved against an array.
+	ErrNotFound pointerError = "JSON pointer location not found"
+
+	// ErrIndexOutOfRange indicates that an array index is beyond the bounds of the array.
+	ErrIndexOutOfRange pointerError = "JSON pointer array index out of range"
+
+	// ErrNilTraversal indicates that a token cannot be resolved against a nil value, e.g. a nil
+	// pointer or map.
+	ErrNilTraversal pointerError = "JSON pointer cannot traverse a nil value"
+
+	// ErrNotSettable indicates that a value cannot be set or removed, e.g. an unexported struct
+	// field, or a document of a type that cannot be mutated.
+	ErrNotSettable pointerError = "JSON pointer value cannot be set"
+
+	// ErrAlreadyExists indicates that a value cannot be added because it already exists, when setting
+	// values with [WithAddOnly].
+	ErrAlreadyExists pointerError = "JSON pointer value already exists"
 )
 
 const dashToken = "-"
 
+// Operations reported by [PointerError].
+const (
+	opGet    = "get"
+	opSet    = "set"
+	opInsert = "insert"
+	opDelete = "delete"
+	opOffset = "offset"
+)
+
+// PointerError records the failure to resolve a json pointer against a document, at a given token.
+//
+// The errors returned by the methods of [Pointer] and [Compiled] that resolve a pointer against a
+// document (e.g. [Pointer.Get], [Pointer.Set], [Pointer.Delete] or [Pointer.Offset]) are of type
+// *PointerError, and may be retrieved with [errors.As]. Like all errors from this package, they
+// match [ErrPointer].
+type PointerError struct {
+	// Op is the operation that failed: "get", "set", "insert", "delete" or "offset".
+	Op string
+
+	// Pointer is the json pointer being resolved.
+	Pointer Pointer
+
+	// TokenIndex is the index of the token that could not be resolved, or -1 when the error does not
+	// relate to a token, e.g. when the document is of a type that cannot be mutated.
+	TokenIndex int
+
+	// Token is the decoded (unescaped) token that could not be resolved.
+	Token string
+
+	// NodeKind is the kind of the node against which the token was resolved. A pointer to a value
+	// is reported with the kind of this value.
+	NodeKind reflect.Kind
+
+	// Cause is the underlying error.
+	Cause error
+}
+
+func (e *PointerError) Error() string {
+	if e.TokenIndex < 0 {
+		return fmt.Sprintf("%s %q: %v", e.Op, e.Pointer.String(), e.Cause)
+	}
+
+	return fmt.Sprintf("%s %q at token %d: %v", e.Op, e.Pointer.String(), e.TokenIndex, e.Cause)
+}
+
+// Unwrap returns the underlying error.
+func (e *PointerError) Unwrap() error {
+	return e.Cause
+}
+
+// Is tells that a [PointerError] is an [ErrPointer].
+func (e *PointerError) Is(target error) bool {
+	return target == ErrPointer
+}
+
+// Resolved returns the prefix of the pointer that was resolved before the error occurred, i.e. the
+// pointer to the node against which the failing token was resolved.
+func (e *PointerError) Resolved() Pointer {
+	return e.Pointer.truncate(max(e.TokenIndex, 0))
+}
+
+// errAt wraps an error that occurred when resolving the token at index i against a node of some kind.
+func (p *Pointer) errAt(op string, i int, kind reflect.Kind, err error) error {
+	return &PointerError{
+		Op:         op,
+		Pointer:    *p,
+		TokenIndex: i,
+		Token:      Unescape(p.referenceTokens[i]),
+		NodeKind:   kind,
+		Cause:      err,
+	}
+}
+
+// errOn wraps an error that does not relate to a token, e.g. an unsupported document.
+func (p *Pointer) errOn(op string, kind reflect.Kind, err error) error {
+	return &PointerError{
+		Op:         op,
+		Pointer:    *p,
+		TokenIndex: -1,
+		NodeKind:   kind,
+		Cause:      err,
+	}
+}
+
+// nodeKind returns the kind of a node, or of the value it points to.
+func nodeKind(node any) reflect.Kind {
+	v := reflect.ValueOf(node)
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		return v.Elem().Kind()
+	}
+
+	return v.Kind()
+}
+
 func errNoKey(key string) error {
-	return fmt.Errorf("object has no key %q: %w", key, ErrPointer)
+	return fmt.Errorf("object has no key %q: %w: %w", key, ErrNotFound, ErrPointer)
+}
+
+func errNoField(name string) error {
+	return fmt.Errorf("object has no field %q: %w: %w", name, ErrNotFound, ErrPointer)
+}
+
+func errNothingToReplace(token string) error {
+	return fmt.Errorf("cannot replace %q: no such value: %w: %w", token, ErrNotFound, ErrPointer)
+}
+
+func errAlreadyExists(token string) error {
+	return fmt.Errorf("cannot add %q: a value already exists: %w: %w", token, ErrAlreadyExists, ErrPointer)
+}
+
+func errTypeMismatch(value any, target reflect.Type) error {
+	return fmt.Errorf("cannot convert value of type %T to %v: %w: %w", value, target, ErrTypeMismatch, ErrPointer)
+}
+
+func errMapKey(token string, keyType reflect.Type) error {
+	return fmt.Errorf("cannot convert token %q to a map key of type %v: %w: %w", token, keyType, ErrTypeMismatch, ErrPointer)
 }
 
 func errOutOfBounds(length, idx int) error {
-	return fmt.Errorf("index out of bounds array[0,%d] index '%d': %w", length-1, idx, ErrPointer)
+	return fmt.Errorf("index out of bounds array[0,%d] index '%d': %w: %w", length-1, idx, ErrIndexOutOfRange, ErrPointer)
+}
+
+func errNotANumber(token string, err error) error {
+	return fmt.Errorf("token reference %q is not a number: %w: %w: %w", token, err, ErrNotFound, ErrPointer)
 }
 
 func errInvalidReference(token string) error {
-	return fmt.Errorf("invalid token reference %q: %w", token, ErrPointer)
+	return fmt.Errorf("invalid token reference %q: %w: %w", token, ErrTypeMismatch, ErrPointer)
+}
+
+func errInvalidEscape(jsonPointerString string, pos int) error {
+	return fmt.Errorf("invalid escape sequence at byte %d in %q: %w: %w", pos, jsonPointerString, ErrInvalidEscape, ErrPointer)
+}
+
+func errInvalidIndex(token string) error {
+	return fmt.Errorf("invalid array index %q: %w: %w", token, ErrInvalidIndex, ErrPointer)
 }
 
 func errDashOnGet() error {
@@ -55,6 +224,30 @@ func errDashIntermediate() error {
 	return fmt.Errorf("the %q token may only appear as the terminal token of a pointer: %w: %w", dashToken, ErrDashToken, ErrPointer)
 }
 
+func errDashOnRemove() error {
+	return fmt.Errorf("cannot remove the %q token (nonexistent element): %w: %w", dashToken, ErrDashToken, ErrPointer)
+}
+
 func errDashOnOffset() error {
 	return fmt.Errorf("cannot compute offset for %q token (nonexistent element): %w: %w", dashToken, ErrDashToken, ErrPointer)
 }
+
+func errDashOnArray() error {
+	return fmt.Errorf("cannot append with the %q token to a go array of fixed size: %w: %w", dashToken, ErrDashToken, ErrPointer)
+}
+
+func errRemoveRoot() error {
+	return fmt.Errorf("cannot remove the root document: %w", ErrPointer)
+}
+
+func errFixedSizeArray(op, token string) error {
+	return fmt.Errorf("cannot %s element %q of a go array of fixed size: %w: %w", op, token, ErrNotSettable, ErrPointer)
+}
+
+func errMissingMember(member string) error {
+	return fmt.Errorf("missing %q member: %w: %w", member, ErrInvalidPatch, ErrPointer)
+}
+
+func errUnknownOperation(op string) error {
+	return fmt.Errorf("unknown operation %q: %w: %w", op, ErrInvalidPatch, ErrPointer)
+}
@@ -0,0 +1,320 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpointer
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/jsonpointer/jsonname"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+var errLookup = errors.New("lookup failed")
+
+type failingPointable struct{}
+
+func (failingPointable) JSONLookup(string) (any, error) {
+	return nil, errLookup
+}
+
+func TestPointerError(t *testing.T) {
+	t.Parallel()
+
+	type document struct {
+		A map[string]any `json:"a"`
+		B []int          `json:"b"`
+	}
+
+	newDocument := func() *document {
+		return &document{
+			A: map[string]any{"x": map[string]any{"y": 1}},
+			B: []int{1, 2},
+		}
+	}
+
+	for _, tc := range []struct {
+		name       string
+		op         func(p Pointer) error
+		pointer    string
+		opName     string
+		tokenIndex int
+		token      string
+		nodeKind   reflect.Kind
+		resolved   string
+	}{
+		{
+			name:    "get missing key",
+			pointer: "/a/x/z",
+			op: func(p Pointer) error {
+				_, _, err := p.Get(newDocument())
+
+				return err
+			},
+			opName: "get", tokenIndex: 2, token: "z", nodeKind: reflect.Map, resolved: "/a/x",
+		},
+		{
+			name:    "get through scalar",
+			pointer: "/a/x/y/z",
+			op: func(p Pointer) error {
+				_, _, err := p.Get(newDocument())
+
+				return err
+			},
+			opName: "get", tokenIndex: 3, token: "z", nodeKind: reflect.Int, resolved: "/a/x/y",
+		},
+		{
+			name:    "get missing field",
+			pointer: "/c~1d",
+			op: func(p Pointer) error {
+				_, _, err := p.Get(newDocument())
+
+				return err
+			},
+			opName: "get", tokenIndex: 0, token: "c/d", nodeKind: reflect.Struct, resolved: "",
+		},
+		{
+			name:    "compiled get out of bounds",
+			pointer: "/b/2",
+			op: func(p Pointer) error {
+				_, _, err := p.Compile().Get(newDocument())
+
+				return err
+			},
+			opName: "get", tokenIndex: 1, token: "2", nodeKind: reflect.Slice, resolved: "/b",
+		},
+		{
+			name:    "set intermediate missing key",
+			pointer: "/a/z/y",
+			op: func(p Pointer) error {
+				_, err := p.Set(newDocument(), 1)
+
+				return err
+			},
+			opName: "set", tokenIndex: 1, token: "z", nodeKind: reflect.Map, resolved: "/a",
+		},
+		{
+			name:    "set type mismatch",
+			pointer: "/b/0",
+			op: func(p Pointer) error {
+				_, err := p.Set(newDocument(), "x")
+
+				return err
+			},
+			opName: "set", tokenIndex: 1, token: "0", nodeKind: reflect.Slice, resolved: "/b",
+		},
+		{
+			name:    "set unsupported document",
+			pointer: "/a",
+			op: func(p Pointer) error {
+				_, err := p.Set(1, 1)
+
+				return err
+			},
+			opName: "set", tokenIndex: -1, nodeKind: reflect.Int, resolved: "",
+		},
+		{
+			name:    "insert out of bounds",
+			pointer: "/b/3",
+			op: func(p Pointer) error {
+				_, err := p.Insert(newDocument(), 3)
+
+				return err
+			},
+			opName: "insert", tokenIndex: 1, token: "3", nodeKind: reflect.Slice, resolved: "/b",
+		},
+		{
+			name:    "delete missing key",
+			pointer: "/a/z",
+			op: func(p Pointer) error {
+				_, err := p.Delete(newDocument())
+
+				return err
+			},
+			opName: "delete", tokenIndex: 1, token: "z", nodeKind: reflect.Map, resolved: "/a",
+		},
+		{
+			name:    "delete root",
+			pointer: "",
+			op: func(p Pointer) error {
+				_, err := p.Delete(newDocument())
+
+				return err
+			},
+			opName: "delete", tokenIndex: -1, nodeKind: reflect.Struct, resolved: "",
+		},
+		{
+			name:    "offset missing key",
+			pointer: "/a/z",
+			op: func(p Pointer) error {
+				_, err := p.Offset(`{"a": {"x": 1}}`)
+
+				return err
+			},
+			opName: "offset", tokenIndex: 1, token: "z", nodeKind: reflect.Map, resolved: "/a",
+		},
+		{
+			name:    "offset through scalar",
+			pointer: "/a/x/0",
+			op: func(p Pointer) error {
+				_, err := p.Offset(`{"a": {"x": "y"}}`)
+
+				return err
+			},
+			opName: "offset", tokenIndex: 2, token: "0", nodeKind: reflect.String, resolved: "/a/x",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p, err := New(tc.pointer)
+			require.NoError(t, err)
+
+			err = tc.op(p)
+			require.ErrorIs(t, err, ErrPointer)
+
+			var pointerErr *PointerError
+			require.ErrorAs(t, err, &pointerErr)
+			assert.EqualT(t, tc.opName, pointerErr.Op)
+			assert.EqualT(t, tc.pointer, pointerErr.Pointer.String())
+			assert.EqualT(t, tc.tokenIndex, pointerErr.TokenIndex)
+			assert.EqualT(t, tc.token, pointerErr.Token)
+			assert.EqualT(t, tc.nodeKind, pointerErr.NodeKind)
+			assert.EqualT(t, tc.resolved, pointerErr.Resolved().String())
+			require.Error(t, pointerErr.Cause)
+		})
+	}
+
+	t.Run("should format the failing operation", func(t *testing.T) {
+		t.Parallel()
+
+		p, err := New("/a/x/z")
+		require.NoError(t, err)
+
+		_, _, err = p.Get(newDocument())
+		require.EqualError(t, err, `get "/a/x/z" at token 2: object has no key "z": JSON pointer location not found: JSON pointer error`)
+
+		_, err = Pointer{}.Delete(newDocument())
+		require.EqualError(t, err, `delete "": cannot remove the root document: JSON pointer error`)
+	})
+
+	t.Run("should match ErrPointer with any cause", func(t *testing.T) {
+		t.Parallel()
+
+		p, err := New("/a/b")
+		require.NoError(t, err)
+
+		_, _, err = p.Get(map[string]any{"a": failingPointable{}})
+		require.ErrorIs(t, err, ErrPointer)
+		require.ErrorIs(t, err, errLookup)
+	})
+}
+
+func TestErrorSentinels(t *testing.T) {
+	t.Parallel()
+
+	type document struct {
+		A map[string]int `json:"a"`
+		B []int          `json:"b"`
+		C *document      `json:"c"`
+		D [2]int         `json:"d"`
+	}
+
+	newDocument := func() *document {
+		return &document{
+			A: map[string]int{"x": 1},
+			B: []int{1, 2},
+		}
+	}
+
+	get := func(document any, opts ...Option) func(p Pointer) error {
+		return func(p Pointer) error {
+			_, _, err := p.Get(document, opts...)
+
+			return err
+		}
+	}
+
+	set := func(document, value any, opts ...Option) func(p Pointer) error {
+		return func(p Pointer) error {
+			_, err := p.Set(document, value, opts...)
+
+			return err
+		}
+	}
+
+	remove := func(document any) func(p Pointer) error {
+		return func(p Pointer) error {
+			_, err := p.Delete(document)
+
+			return err
+		}
+	}
+
+	offset := func(document string) func(p Pointer) error {
+		return func(p Pointer) error {
+			_, err := p.Offset(document)
+
+			return err
+		}
+	}
+
+	sentinels := []error{ErrNotFound, ErrIndexOutOfRange, ErrTypeMismatch, ErrNilTraversal, ErrNotSettable, ErrAlreadyExists}
+
+	for _, tc := range []struct {
+		name     string
+		pointer  string
+		op       func(p Pointer) error
+		sentinel error
+	}{
+		{name: "get missing JSON key", pointer: "/x", op: get(map[string]any{}), sentinel: ErrNotFound},
+		{name: "get missing map key", pointer: "/a/y", op: get(newDocument()), sentinel: ErrNotFound},
+		{name: "get missing field", pointer: "/e", op: get(newDocument()), sentinel: ErrNotFound},
+		{name: "get JSON index out of range", pointer: "/1", op: get([]any{1}), sentinel: ErrIndexOutOfRange},
+		{name: "get index out of range", pointer: "/b/2", op: get(newDocument()), sentinel: ErrIndexOutOfRange},
+		{name: "get through scalar", pointer: "/a/x/y", op: get(newDocument()), sentinel: ErrTypeMismatch},
+		{name: "get through nil pointer", pointer: "/c/a", op: get(newDocument()), sentinel: ErrNilTraversal},
+		{name: "get through nil value", pointer: "/x/y", op: get(map[string]any{"x": nil}), sentinel: ErrNilTraversal},
+		{name: "get through nil embedded struct", pointer: "/promoted", op: get(compiledDoc{}, WithNameProvider(jsonname.NewGoNameProvider())), sentinel: ErrNilTraversal},
+		{name: "set through nil value", pointer: "/x/y", op: set(map[string]any{"x": nil}, 1), sentinel: ErrNilTraversal},
+		{name: "set index out of range", pointer: "/b/3", op: set(newDocument(), 1), sentinel: ErrIndexOutOfRange},
+		{name: "set mismatching value", pointer: "/b/0", op: set(newDocument(), "x"), sentinel: ErrTypeMismatch},
+		{name: "set mismatching map key", pointer: "/x", op: set(map[int]int{}, 1), sentinel: ErrTypeMismatch},
+		{name: "set missing value", pointer: "/a/y", op: set(newDocument(), 1, WithReplaceOnly()), sentinel: ErrNotFound},
+		{name: "set existing value", pointer: "/a/x", op: set(newDocument(), 1, WithAddOnly()), sentinel: ErrAlreadyExists},
+		{name: "set field of struct value", pointer: "/a", op: set(document{}, map[string]int{}), sentinel: ErrNotSettable},
+		{name: "set unsupported document", pointer: "/a", op: set(1, 1), sentinel: ErrNotSettable},
+		{name: "delete missing key", pointer: "/a/y", op: remove(newDocument()), sentinel: ErrNotFound},
+		{name: "delete index out of range", pointer: "/b/2", op: remove(newDocument()), sentinel: ErrIndexOutOfRange},
+		{name: "delete element of go array", pointer: "/d/0", op: remove(newDocument()), sentinel: ErrNotSettable},
+		{name: "delete field of struct value", pointer: "/a", op: remove(document{}), sentinel: ErrNotSettable},
+		{name: "offset missing key", pointer: "/a/y", op: offset(`{"a": {"x": 1}}`), sentinel: ErrNotFound},
+		{name: "offset index out of range", pointer: "/a/1", op: offset(`{"a": [1]}`), sentinel: ErrIndexOutOfRange},
+		{name: "offset through scalar", pointer: "/a/x", op: offset(`{"a": 1}`), sentinel: ErrTypeMismatch},
+		{name: "get non-numeric array token", pointer: "/arr/foo", op: get(map[string]any{"arr": []any{1}}), sentinel: ErrNotFound},
+		{name: "get non-numeric token of a typed slice", pointer: "/b/foo", op: get(newDocument()), sentinel: ErrNotFound},
+		{name: "set non-numeric array token", pointer: "/arr/foo", op: set(map[string]any{"arr": []any{1}}, 2), sentinel: ErrNotFound},
+		{name: "delete non-numeric array token", pointer: "/arr/foo", op: remove(map[string]any{"arr": []any{1}}), sentinel: ErrNotFound},
+		{name: "offset non-numeric array token", pointer: "/arr/foo", op: offset(`{"arr": [1]}`), sentinel: ErrNotFound},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p, err := New(tc.pointer)
+			require.NoError(t, err)
+
+			err = tc.op(p)
+			require.ErrorIs(t, err, ErrPointer)
+			require.ErrorIs(t, err, tc.sentinel)
+
+			for _, other := range sentinels {
+				if other != tc.sentinel {
+					require.NotErrorIs(t, err, other)
+				}
+			}
+		})
+	}
+}
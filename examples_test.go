@@ -232,3 +232,164 @@ func ExampleUseGoNameProvider() {
 	// /Untagged -> default=<nil> (err=true) | goname=hidden-by-default (err=false)
 	// /Nested -> default=<nil> (err=true) | goname=promoted (err=false)
 }
+
+func ExampleApplyPatch() {
+	var doc any
+	if err := json.Unmarshal([]byte(`{"foo": ["bar", "baz"]}`), &doc); err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	patch, err := DecodePatch([]byte(`[
+		{"op": "add", "path": "/foo/1", "value": "qux"},
+		{"op": "remove", "path": "/foo/0"},
+		{"op": "copy", "from": "/foo", "path": "/copied"},
+		{"op": "test", "path": "/copied/1", "value": "baz"}
+	]`))
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	result, err := ApplyPatch(doc, patch)
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	out, _ := json.Marshal(result)
+	fmt.Println(string(out))
+
+	// Output: {"copied":["qux","baz"],"foo":["qux","baz"]}
+}
+
+func ExamplePointer_Delete() {
+	doc := map[string]any{"foo": []any{"bar", "baz", "qux"}}
+
+	pointer, err := New("/foo/1")
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	if _, err := pointer.Delete(doc); err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	fmt.Printf("doc: %v\n", doc["foo"])
+
+	// Output: doc: [bar qux]
+}
+
+func ExamplePointer_Insert() {
+	doc := map[string]any{"foo": []any{"bar", "qux"}}
+
+	pointer, err := New("/foo/1")
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	if _, err := pointer.Insert(doc, "baz"); err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	fmt.Printf("doc: %v\n", doc["foo"])
+
+	// Output: doc: [bar baz qux]
+}
+
+func ExampleLookup() {
+	var doc any
+
+	if err := json.Unmarshal([]byte(`{"server": {"port": 8080, "hosts": ["a", "b"]}}`), &doc); err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	// the JSON number decoded as a float64 is converted to an int
+	port, err := Lookup[int](doc, "/server/port")
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+	fmt.Printf("port: %d\n", port)
+
+	hosts, err := Lookup[[]any](doc, "/server/hosts")
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+	fmt.Printf("hosts: %v\n", hosts)
+
+	_, err = Lookup[string](doc, "/server/port")
+	fmt.Printf("mismatch: %v\n", errors.Is(err, ErrTypeMismatch))
+
+	// Output:
+	// port: 8080
+	// hosts: [a b]
+	// mismatch: true
+}
+
+func ExampleWalk() {
+	var doc any
+
+	if err := json.Unmarshal([]byte(`{"foo": ["bar", "baz"], "qux": {"quux": 1}}`), &doc); err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	for pointer, value := range Walk(doc, WithSortedKeys(), WithLeavesOnly()) {
+		fmt.Printf("%s: %v\n", pointer, value)
+	}
+
+	// Output:
+	// /foo/0: bar
+	// /foo/1: baz
+	// /qux/quux: 1
+}
+
+func ExamplePattern_Find() {
+	var doc any
+
+	if err := json.Unmarshal([]byte(`{
+		"paths": {
+			"/pets": {
+				"get": {"responses": {"200": {"description": "pets"}}},
+				"post": {"responses": {"201": {"description": "created"}}}
+			}
+		}
+	}`), &doc); err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	pattern, err := NewPattern("/paths/*/*/responses/*/description")
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	for pointer, value := range pattern.Find(doc, WithSortedKeys()) {
+		fmt.Printf("%s: %v\n", pointer, value)
+	}
+
+	// Output:
+	// /paths/~1pets/get/responses/200/description: pets
+	// /paths/~1pets/post/responses/201/description: created
+}
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpointer
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const fragmentPrefix = `#`
+
+// NewFromFragment creates a new json pointer from its URI fragment identifier representation, as
+// defined by RFC 6901 §6.
+//
+// The fragment must start with "#" and is percent-decoded according to RFC 3986 before being parsed
+// like in [New].
+//
+// Example: "#/paths/~1pets~1%7Bid%7D/get" is the pointer to the key "get" under the key "/pets/{id}"
+// under the key "paths".
+func NewFromFragment(fragment string) (Pointer, error) {
+	if !strings.HasPrefix(fragment, fragmentPrefix) {
+		return Pointer{}, fmt.Errorf("JSON pointer URI fragment must start with %q: %w", fragmentPrefix, ErrPointer)
+	}
+
+	jsonPointerString, err := url.PathUnescape(fragment[len(fragmentPrefix):])
+	if err != nil {
+		return Pointer{}, errors.Join(err, ErrPointer)
+	}
+
+	return New(jsonPointerString)
+}
+
+// Fragment returns the URI fragment identifier representation of a pointer, as defined by
+// RFC 6901 §6.
+//
+// Reference tokens are escaped like in [Pointer.String], then characters that are not allowed in a
+// URI fragment by RFC 3986 are percent-encoded (as UTF-8 bytes).
+//
+// The empty pointer is represented by "#".
+func (p Pointer) Fragment() string {
+	s := p.String()
+
+	var b strings.Builder
+	b.Grow(len(fragmentPrefix) + len(s))
+	b.WriteString(fragmentPrefix)
+
+	const upperhex = "0123456789ABCDEF"
+	for i := range len(s) {
+		c := s[i]
+		if isFragmentChar(c) {
+			b.WriteByte(c)
+
+			continue
+		}
+
+		b.WriteByte('%')
+		b.WriteByte(upperhex[c>>4])
+		b.WriteByte(upperhex[c&0x0f])
+	}
+
+	return b.String()
+}
+
+// isFragmentChar tells if a byte may appear unencoded in a URI fragment.
+//
+// RFC 3986 §3.5: fragment = *( pchar / "/" / "?" ), with pchar = unreserved / pct-encoded /
+// sub-delims / ":" / "@".
+func isFragmentChar(c byte) bool {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		return true
+	}
+
+	switch c {
+	case '-', '.', '_', '~', // unreserved
+		'!', '$', '&', '\'', '(', ')', '*', '+', ',', ';', '=', // sub-delims
+		':', '@', '/', '?':
+		return true
+	default:
+		return false
+	}
+}
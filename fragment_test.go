@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpointer
+
+import (
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestFragment(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RFC 6901 section 6 examples against test document", func(t *testing.T) {
+		fragments := []string{`#/`, `#/a~1b`, `#/c%25d`, `#/e%5Ef`, `#/g%7Ch`, `#/i%5Cj`, `#/k%22l`, `#/%20`, `#/m~0n`}
+		pointers := []string{`/`, `/a~1b`, `/c%d`, `/e^f`, `/g|h`, `/i\j`, `/k"l`, `/ `, `/m~0n`}
+		outs := []float64{0, 1, 2, 3, 4, 5, 6, 7, 8}
+
+		for i, fragment := range fragments {
+			p, err := NewFromFragment(fragment)
+			require.NoError(t, err, "input: %v", fragment)
+			assert.EqualT(t, pointers[i], p.String())
+			assert.EqualT(t, fragment, p.Fragment())
+
+			result, _, err := p.Get(testDocumentJSON(t))
+			require.NoError(t, err, "input: %v", fragment)
+			assert.InDeltaf(t, outs[i], result, 1e-6, "input: %v", fragment)
+		}
+	})
+
+	t.Run("with whole document", func(t *testing.T) {
+		p, err := NewFromFragment("#")
+		require.NoError(t, err)
+		assert.TrueT(t, p.IsEmpty())
+		assert.EqualT(t, "#", p.Fragment())
+	})
+
+	t.Run("with OpenAPI path template", func(t *testing.T) {
+		const fragment = `#/paths/~1pets~1%7Bid%7D/get`
+
+		p, err := NewFromFragment(fragment)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"paths", "/pets/{id}", "get"}, p.DecodedTokens())
+		assert.EqualT(t, fragment, p.Fragment())
+	})
+
+	t.Run("with non-ASCII characters", func(t *testing.T) {
+		p, err := New("/café/a b#c")
+		require.NoError(t, err)
+		assert.EqualT(t, "#/caf%C3%A9/a%20b%23c", p.Fragment())
+
+		q, err := NewFromFragment(p.Fragment())
+		require.NoError(t, err)
+		assert.Equal(t, p.DecodedTokens(), q.DecodedTokens())
+	})
+
+	t.Run("should keep allowed characters unencoded", func(t *testing.T) {
+		p, err := New("/a:b@c/d?e/f!$&'()*+,;=/-._~0")
+		require.NoError(t, err)
+		assert.EqualT(t, "#/a:b@c/d?e/f!$&'()*+,;=/-._~0", p.Fragment())
+	})
+
+	t.Run("should reject invalid fragments", func(t *testing.T) {
+		for _, input := range []string{``, `/foo`, `#foo`, `#/foo%`, `#/foo%zz`} {
+			_, err := NewFromFragment(input)
+			require.Error(t, err, "input: %q", input)
+			require.ErrorIs(t, err, ErrPointer, "input: %q", input)
+		}
+	})
+}
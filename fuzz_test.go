@@ -29,6 +29,23 @@ func FuzzParse(f *testing.F) {
 	})
 }
 
+func FuzzFragment(f *testing.F) {
+	for generator := range generators() {
+		f.Add(generator)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		p, err := New(input)
+		if err != nil {
+			return
+		}
+
+		q, err := NewFromFragment(p.Fragment())
+		require.NoError(t, err)
+		require.EqualT(t, p.String(), q.String())
+	})
+}
+
 func generators() iter.Seq[string] {
 	return slices.Values([]string{
 		`a`,
@@ -145,6 +145,6 @@ func Example_iface() {
 	// Output:
 	// propA (string): initial value for a
 	// propB (string): initial value for b
-	// propC: key "extra" not found: example error
+	// propC: get "/extra" at token 0: key "extra" not found: example error
 	// updated doc: {new value for a new value for b map[extra:new extra value]}
 }
@@ -37,6 +37,19 @@ type JSONSetable interface {
 	JSONSet(key string, value any) error
 }
 
+// JSONDeletable is an interface for structs to implement, when they need to customize the json
+// pointer process or want to avoid the use of reflection.
+//
+// When a type implementing JSONDeletable is the terminal parent of a [Pointer.Delete] call, the
+// library delegates the removal to JSONDelete.
+//
+// Like for [JSONSetable], implementations are responsible for any in-place mutation: the library
+// does not attempt to rebind the parent into its own container.
+type JSONDeletable interface {
+	// JSONDelete removes the value pointed at the (unescaped) key.
+	JSONDelete(key string) error
+}
+
 // NameProvider knows how to resolve go struct fields into json names.
 //
 // The default provider is brought by
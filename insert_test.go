@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpointer
+
+import (
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestInsert(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should insert into a slice nested in a map", func(t *testing.T) {
+		doc := map[string]any{"items": []any{"a", "b", "c"}}
+		p, err := New("/items/1")
+		require.NoError(t, err)
+
+		_, err = p.Insert(doc, "x")
+		require.NoError(t, err)
+		assert.Equal(t, []any{"a", "x", "b", "c"}, doc["items"])
+	})
+
+	t.Run("should insert at the length of the slice", func(t *testing.T) {
+		doc := map[string]any{"items": []any{"a"}}
+		p, err := New("/items/1")
+		require.NoError(t, err)
+
+		_, err = p.Insert(doc, "x")
+		require.NoError(t, err)
+		assert.Equal(t, []any{"a", "x"}, doc["items"])
+	})
+
+	t.Run("should append with the dash token", func(t *testing.T) {
+		doc := map[string]any{"items": []any{"a"}}
+		p, err := New("/items/-")
+		require.NoError(t, err)
+
+		_, err = p.Insert(doc, "x")
+		require.NoError(t, err)
+		assert.Equal(t, []any{"a", "x"}, doc["items"])
+	})
+
+	t.Run("should insert into a slice field in place", func(t *testing.T) {
+		type item struct {
+			V int `json:"v"`
+		}
+		doc := struct {
+			Items []item `json:"items"`
+		}{Items: []item{{V: 1}, {V: 3}}}
+
+		p, err := New("/items/1")
+		require.NoError(t, err)
+
+		_, err = p.Insert(&doc, item{V: 2})
+		require.NoError(t, err)
+		assert.Equal(t, []item{{V: 1}, {V: 2}, {V: 3}}, doc.Items)
+	})
+
+	t.Run("should insert into a top-level *[]T in place", func(t *testing.T) {
+		doc := []int{1, 3}
+		p, err := New("/0")
+		require.NoError(t, err)
+
+		_, err = p.Insert(&doc, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 1, 3}, doc)
+	})
+
+	t.Run("should return a new slice for a top-level slice passed by value", func(t *testing.T) {
+		doc := []int{1, 3}
+		p, err := New("/1")
+		require.NoError(t, err)
+
+		out, err := p.Insert(doc, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, out)
+		assert.Equal(t, []int{1, 3}, doc)
+	})
+
+	t.Run("should set map entries and struct fields", func(t *testing.T) {
+		doc := map[string]any{"s": &testStructJSON{}}
+
+		p, err := New("/k")
+		require.NoError(t, err)
+		_, err = p.Insert(doc, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 1, doc["k"])
+
+		p, err = New("/s/obj/a")
+		require.NoError(t, err)
+		_, err = p.Insert(doc, 12)
+		require.NoError(t, err)
+		assert.EqualT(t, 12, doc["s"].(*testStructJSON).Obj.A) //nolint:forcetypeassert // set above
+	})
+
+	t.Run("with InsertForToken", func(t *testing.T) {
+		doc := []int{2}
+
+		out, err := InsertForToken(doc, "0", 1)
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2}, out)
+	})
+}
+
+func TestInsert_Errors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		pointer string
+		value   any
+		substr  string
+	}{
+		{name: "index past the length", pointer: "/items/3", value: 1, substr: "out of bounds"},
+		{name: "negative index", pointer: "/items/-1", value: 1, substr: "out of bounds"},
+		{name: "non-numeric index", pointer: "/items/x", value: 1, substr: `parsing "x"`},
+		{name: "mismatching type", pointer: "/ints/0", value: "x", substr: "can't insert value of type string"},
+		{name: "missing parent", pointer: "/missing/0", value: 1, substr: `no key "missing"`},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := map[string]any{"items": []any{1, 2}, "ints": []int{1}}
+
+			p, err := New(tt.pointer)
+			require.NoError(t, err)
+
+			_, err = p.Insert(doc, tt.value)
+			require.Error(t, err)
+			require.ErrorIs(t, err, ErrPointer)
+			require.ErrorContains(t, err, tt.substr)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+// Package identity identifies the reference values of a document, which may be shared by several
+// locations and introduce cycles.
+package identity
+
+import "reflect"
+
+// Key identifies a reference value: a pointer, a map or a non-empty slice.
+//
+// Slices sharing the same backing array but with different lengths have different keys.
+type Key struct {
+	ptr    uintptr
+	tpe    reflect.Type
+	length int
+}
+
+// Of returns the [Key] of a value, or false if the value is not a reference value.
+func Of(value any) (Key, bool) {
+	return OfValue(reflect.ValueOf(value))
+}
+
+// OfValue returns the [Key] of a [reflect.Value], like [Of].
+func OfValue(rValue reflect.Value) (Key, bool) {
+	switch rValue.Kind() {
+	case reflect.Pointer, reflect.Map:
+		if rValue.IsNil() {
+			return Key{}, false
+		}
+
+		return Key{ptr: rValue.Pointer(), tpe: rValue.Type()}, true
+	case reflect.Slice:
+		if rValue.Len() == 0 {
+			return Key{}, false
+		}
+
+		return Key{ptr: rValue.Pointer(), tpe: rValue.Type(), length: rValue.Len()}, true
+	default:
+		return Key{}, false
+	}
+}
@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+// Package jsonpath evaluates JSONPath queries, as specified by [RFC 9535], over go values.
+//
+// Queries are evaluated over the same go value model as [jsonpointer.Pointer.Get]: go maps and
+// structs are objects, go slices and arrays are arrays, struct fields are named by the configured
+// [jsonpointer.NameProvider], and values implementing [jsonpointer.JSONPointable] are supported.
+//
+// Each value selected by a query is returned along with its location, as a
+// [jsonpointer.Pointer] and as an RFC 9535 normalized path.
+//
+// # Supported syntax
+//
+// The following selectors are supported:
+//
+//   - name selectors, e.g. $.store or $['store']
+//   - the wildcard selector, e.g. $.store.* or $.store[*]
+//   - index selectors, e.g. $.book[0] or $.book[-1]
+//   - array slice selectors, e.g. $.book[1:3] or $.book[::-1]
+//   - filter selectors, e.g. $.book[?@.price < 10 && @.isbn]
+//
+// Selectors are used in child segments, e.g. $.book[0, 1], or in descendant segments, e.g.
+// $..price.
+//
+// Filter expressions support comparisons ("==", "!=", "<", "<=", ">", ">="), existence tests and
+// the logical operators "&&", "||" and "!".
+//
+// Function extensions (e.g. length() or match()) are not supported.
+//
+// [RFC 9535]: https://www.rfc-editor.org/rfc/rfc9535
+package jsonpath
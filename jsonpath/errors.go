@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpath
+
+import "fmt"
+
+type pathError string
+
+func (e pathError) Error() string {
+	return string(e)
+}
+
+const (
+	// ErrPath is a sentinel error raised by all errors from this package.
+	ErrPath pathError = "JSONPath error"
+
+	// ErrUnsupported indicates that a JSONPath query uses a feature of RFC 9535 that is not supported
+	// by this package, such as function extensions.
+	ErrUnsupported pathError = "unsupported JSONPath feature"
+)
+
+func errSyntax(expr string, pos int, msg string) error {
+	return fmt.Errorf("invalid JSONPath %q at offset %d: %s: %w", expr, pos, msg, ErrPath)
+}
+
+func errUnsupportedFunction(expr string, pos int, name string) error {
+	return fmt.Errorf("invalid JSONPath %q at offset %d: function %s() is not supported: %w: %w", expr, pos, name, ErrUnsupported, ErrPath)
+}
@@ -0,0 +1,367 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpath
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/jsonpointer"
+	"github.com/go-openapi/jsonpointer/internal/identity"
+)
+
+// node is a value of the document, along with its location.
+type node struct {
+	value   any
+	pointer jsonpointer.Pointer
+	path    string
+}
+
+// member is a child value of an object or an array, along with its decoded token.
+type member struct {
+	token string
+	value any
+}
+
+type segment struct {
+	descendant bool
+	selectors  []selector
+}
+
+// selector selects children of a node, and appends them to out.
+type selector interface {
+	apply(e *evaluator, n node, out []node) []node
+}
+
+type (
+	nameSelector     struct{ name string }
+	wildcardSelector struct{}
+	indexSelector    struct{ index int }
+	filterSelector   struct{ expr logicalExpr }
+)
+
+type sliceSelector struct {
+	start, end       int
+	hasStart, hasEnd bool
+	step             int
+}
+
+type nodeKind uint8
+
+const (
+	scalarKind nodeKind = iota
+	objectKind
+	arrayKind
+)
+
+// evaluator evaluates queries against a document.
+type evaluator struct {
+	root     node
+	opts     []jsonpointer.Option
+	walkOpts []jsonpointer.Option
+}
+
+func newEvaluator(document any, opts []jsonpointer.Option) *evaluator {
+	// children are listed by a walk limited to the first level, whatever the options
+	walkOpts := make([]jsonpointer.Option, 0, len(opts)+1)
+	walkOpts = append(walkOpts, opts...)
+	walkOpts = append(walkOpts, jsonpointer.WithMaxDepth(1))
+
+	return &evaluator{
+		root:     node{value: document, path: "$"},
+		opts:     opts,
+		walkOpts: walkOpts,
+	}
+}
+
+// query applies the segments of a query in turn, starting with a single node.
+func (e *evaluator) query(start node, segments []segment) []node {
+	nodes := []node{start}
+
+	for _, seg := range segments {
+		var next []node
+
+		for _, n := range nodes {
+			if seg.descendant {
+				next = e.descendants(n, seg.selectors, next, make(map[identity.Key]struct{}))
+
+				continue
+			}
+
+			for _, sel := range seg.selectors {
+				next = sel.apply(e, n, next)
+			}
+		}
+
+		if len(next) == 0 {
+			return nil
+		}
+
+		nodes = next
+	}
+
+	return nodes
+}
+
+// descendants applies the selectors to a node and to all its descendants, with nodes visited before
+// their descendants.
+//
+// Cycles in pointer graphs are detected: a value is not visited again by its own descendants.
+func (e *evaluator) descendants(n node, selectors []selector, out []node, visiting map[identity.Key]struct{}) []node {
+	for _, sel := range selectors {
+		out = sel.apply(e, n, out)
+	}
+
+	key, tracked := identity.Of(n.value)
+	if tracked {
+		if _, cyclic := visiting[key]; cyclic {
+			return out
+		}
+
+		visiting[key] = struct{}{}
+		defer delete(visiting, key)
+	}
+
+	for _, child := range e.children(n) {
+		out = e.descendants(child, selectors, out, visiting)
+	}
+
+	return out
+}
+
+// children lists the members of an object or the elements of an array.
+func (e *evaluator) children(n node) []node {
+	kind := kindOf(n.value)
+	members := e.members(n.value, kind)
+
+	children := make([]node, 0, len(members))
+	for _, m := range members {
+		if kind == arrayKind {
+			idx, _ := strconv.Atoi(m.token)
+			children = append(children, indexChild(n, idx, m.value))
+
+			continue
+		}
+
+		children = append(children, nameChild(n, m.token, m.value))
+	}
+
+	return children
+}
+
+// members lists the children of a value, like [jsonpointer.Walk] does.
+func (e *evaluator) members(value any, kind nodeKind) []member {
+	if kind == scalarKind {
+		return nil
+	}
+
+	var members []member
+	for p, child := range jsonpointer.Walk(value, e.walkOpts...) {
+		token, ok := p.Last()
+		if !ok {
+			// the value itself
+			continue
+		}
+
+		members = append(members, member{token: token, value: child})
+	}
+
+	return members
+}
+
+func (s nameSelector) apply(e *evaluator, n node, out []node) []node {
+	if kindOf(n.value) != objectKind {
+		return out
+	}
+
+	value, _, err := jsonpointer.GetForToken(n.value, s.name, e.opts...)
+	if err != nil {
+		return out
+	}
+
+	return append(out, nameChild(n, s.name, value))
+}
+
+func (wildcardSelector) apply(e *evaluator, n node, out []node) []node {
+	return append(out, e.children(n)...)
+}
+
+func (s indexSelector) apply(e *evaluator, n node, out []node) []node {
+	length, ok := arrayLen(n.value)
+	if !ok {
+		return out
+	}
+
+	idx := s.index
+	if idx < 0 {
+		idx += length
+	}
+
+	if idx < 0 || idx >= length {
+		return out
+	}
+
+	return e.appendElement(n, idx, out)
+}
+
+func (s sliceSelector) apply(e *evaluator, n node, out []node) []node {
+	length, ok := arrayLen(n.value)
+	if !ok || s.step == 0 {
+		return out
+	}
+
+	normalize := func(i int) int {
+		if i >= 0 {
+			return i
+		}
+
+		return length + i
+	}
+
+	if s.step > 0 {
+		lower, upper := 0, length
+		if s.hasStart {
+			lower = min(max(normalize(s.start), 0), length)
+		}
+		if s.hasEnd {
+			upper = min(max(normalize(s.end), 0), length)
+		}
+
+		for i := lower; i < upper; i += s.step {
+			out = e.appendElement(n, i, out)
+		}
+
+		return out
+	}
+
+	upper, lower := length-1, -1
+	if s.hasStart {
+		upper = min(max(normalize(s.start), -1), length-1)
+	}
+	if s.hasEnd {
+		lower = min(max(normalize(s.end), -1), length-1)
+	}
+
+	for i := upper; lower < i; i += s.step {
+		out = e.appendElement(n, i, out)
+	}
+
+	return out
+}
+
+func (s filterSelector) apply(e *evaluator, n node, out []node) []node {
+	for _, child := range e.children(n) {
+		if s.expr.test(e, child) {
+			out = append(out, child)
+		}
+	}
+
+	return out
+}
+
+func (e *evaluator) appendElement(n node, idx int, out []node) []node {
+	value, _, err := jsonpointer.GetForToken(n.value, strconv.Itoa(idx), e.opts...)
+	if err != nil {
+		return out
+	}
+
+	return append(out, indexChild(n, idx, value))
+}
+
+func nameChild(parent node, name string, value any) node {
+	return node{
+		value:   value,
+		pointer: parent.pointer.Append(name),
+		path:    parent.path + "[" + quoteName(name) + "]",
+	}
+}
+
+func indexChild(parent node, idx int, value any) node {
+	return node{
+		value:   value,
+		pointer: parent.pointer.AppendIndex(idx),
+		path:    parent.path + "[" + strconv.Itoa(idx) + "]",
+	}
+}
+
+// quoteName quotes a member name as specified for normalized paths by RFC 9535.
+func quoteName(name string) string {
+	var b strings.Builder
+
+	b.Grow(len(name) + 2)
+	b.WriteByte('\'')
+
+	for _, r := range name {
+		switch r {
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\'':
+			b.WriteString(`\'`)
+		case '\\':
+			b.WriteString(`\\`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&b, `\u%04x`, r)
+
+				continue
+			}
+
+			b.WriteRune(r)
+		}
+	}
+
+	b.WriteByte('\'')
+
+	return b.String()
+}
+
+// indirect dereferences pointers and interfaces.
+//
+// It returns an invalid value for nil.
+func indirect(value any) reflect.Value {
+	rv := reflect.ValueOf(value)
+
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return reflect.Value{}
+		}
+
+		rv = rv.Elem()
+	}
+
+	return rv
+}
+
+// kindOf tells if a value is interpreted as a JSON object, a JSON array or a scalar.
+func kindOf(value any) nodeKind {
+	switch indirect(value).Kind() {
+	case reflect.Map, reflect.Struct:
+		return objectKind
+	case reflect.Slice, reflect.Array:
+		return arrayKind
+	default:
+		return scalarKind
+	}
+}
+
+func arrayLen(value any) (int, bool) {
+	rv := indirect(value)
+
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		return rv.Len(), true
+	default:
+		return 0, false
+	}
+}
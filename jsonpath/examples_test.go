@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpath_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-openapi/jsonpointer/jsonpath"
+)
+
+func ExamplePath_Query() {
+	var doc any
+
+	if err := json.Unmarshal([]byte(`{
+		"store": {
+			"book": [
+				{"title": "Sayings of the Century", "price": 8.95},
+				{"title": "Sword of Honour", "price": 12.99},
+				{"title": "Moby Dick", "price": 8.99}
+			]
+		}
+	}`), &doc); err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	path, err := jsonpath.Parse("$.store.book[?@.price < 10].title")
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	for _, match := range path.Query(doc) {
+		fmt.Printf("%s (%s): %v\n", match.Path, match.Pointer, match.Value)
+	}
+
+	// Output:
+	// $['store']['book'][0]['title'] (/store/book/0/title): Sayings of the Century
+	// $['store']['book'][2]['title'] (/store/book/2/title): Moby Dick
+}
@@ -0,0 +1,304 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpath
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+
+	"github.com/go-openapi/jsonpointer/internal/identity"
+)
+
+//nolint:gochecknoglobals // it's okay to declare a reflect.Type as a private global
+var jsonNumberType = reflect.TypeFor[json.Number]()
+
+// logicalExpr is a filter expression, tested against the current node "@".
+type logicalExpr interface {
+	test(e *evaluator, current node) bool
+}
+
+type (
+	orExpr    []logicalExpr
+	andExpr   []logicalExpr
+	notExpr   struct{ expr logicalExpr }
+	existExpr struct{ query filterQuery }
+)
+
+type comparisonExpr struct {
+	op          comparisonOp
+	left, right operand
+}
+
+type comparisonOp uint8
+
+const (
+	opEqual comparisonOp = iota
+	opNotEqual
+	opLess
+	opLessOrEqual
+	opGreater
+	opGreaterOrEqual
+)
+
+// operand is an operand of a comparison.
+//
+// It yields false when it has no value, which RFC 9535 calls "Nothing".
+type operand interface {
+	value(e *evaluator, current node) (any, bool)
+}
+
+type literal struct {
+	v any // nil, bool, float64 or string
+}
+
+// filterQuery is a query embedded in a filter expression, relative to the current node "@" or to the
+// root node "$".
+type filterQuery struct {
+	relative bool
+	segments []segment
+}
+
+func (x orExpr) test(e *evaluator, current node) bool {
+	for _, expr := range x {
+		if expr.test(e, current) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (x andExpr) test(e *evaluator, current node) bool {
+	for _, expr := range x {
+		if !expr.test(e, current) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (x notExpr) test(e *evaluator, current node) bool {
+	return !x.expr.test(e, current)
+}
+
+func (x existExpr) test(e *evaluator, current node) bool {
+	return len(x.query.nodes(e, current)) > 0
+}
+
+func (x comparisonExpr) test(e *evaluator, current node) bool {
+	left, hasLeft := x.left.value(e, current)
+	right, hasRight := x.right.value(e, current)
+
+	switch x.op {
+	case opEqual:
+		return e.equal(left, hasLeft, right, hasRight)
+	case opNotEqual:
+		return !e.equal(left, hasLeft, right, hasRight)
+	case opLess:
+		return less(left, hasLeft, right, hasRight)
+	case opLessOrEqual:
+		return less(left, hasLeft, right, hasRight) || e.equal(left, hasLeft, right, hasRight)
+	case opGreater:
+		return less(right, hasRight, left, hasLeft)
+	case opGreaterOrEqual:
+		return less(right, hasRight, left, hasLeft) || e.equal(left, hasLeft, right, hasRight)
+	default:
+		return false
+	}
+}
+
+func (x literal) value(_ *evaluator, _ node) (any, bool) {
+	return x.v, true
+}
+
+func (q filterQuery) nodes(e *evaluator, current node) []node {
+	start := e.root
+	if q.relative {
+		start = current
+	}
+
+	return e.query(start, q.segments)
+}
+
+// value of a singular query, i.e. a query that selects at most one node.
+func (q filterQuery) value(e *evaluator, current node) (any, bool) {
+	nodes := q.nodes(e, current)
+	if len(nodes) != 1 {
+		return nil, false
+	}
+
+	return nodes[0].value, true
+}
+
+// isSingular tells if the query selects at most one node: it may only use name and index selectors
+// in child segments.
+func (q filterQuery) isSingular() bool {
+	for _, seg := range q.segments {
+		if seg.descendant || len(seg.selectors) != 1 {
+			return false
+		}
+
+		switch seg.selectors[0].(type) {
+		case nameSelector, indexSelector:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// equal compares two values as specified by RFC 9535: Nothing only equals Nothing, numbers are
+// compared by value, and objects and arrays are compared deeply.
+func (e *evaluator) equal(left any, hasLeft bool, right any, hasRight bool) bool {
+	if !hasLeft || !hasRight {
+		return hasLeft == hasRight
+	}
+
+	return e.equalValues(left, right)
+}
+
+func (e *evaluator) equalValues(left, right any) bool {
+	left, right = normalize(left), normalize(right)
+
+	if equal, isScalar := equalScalars(left, right); isScalar {
+		return equal
+	}
+
+	kind := kindOf(left)
+	if kind == scalarKind || kindOf(right) != kind {
+		return false
+	}
+
+	if key, ok := identity.Of(left); ok {
+		if other, ok := identity.Of(right); ok && key == other {
+			return true
+		}
+	}
+
+	leftMembers, rightMembers := e.members(left, kind), e.members(right, kind)
+	if len(leftMembers) != len(rightMembers) {
+		return false
+	}
+
+	if kind == arrayKind {
+		return e.equalElements(leftMembers, rightMembers)
+	}
+
+	return e.equalMembers(leftMembers, rightMembers)
+}
+
+// equalScalars compares two normalized values when the left one is a JSON null, number, string or
+// boolean. It returns false as its second result when the left value is not such a scalar.
+func equalScalars(left, right any) (equal bool, isScalar bool) {
+	switch l := left.(type) {
+	case nil:
+		return right == nil, true
+	case float64:
+		r, ok := right.(float64)
+
+		return ok && l == r, true
+	case string:
+		r, ok := right.(string)
+
+		return ok && l == r, true
+	case bool:
+		r, ok := right.(bool)
+
+		return ok && l == r, true
+	default:
+		return false, false
+	}
+}
+
+// equalElements compares the elements of two arrays of the same length, in order.
+func (e *evaluator) equalElements(left, right []member) bool {
+	for i := range left {
+		if !e.equalValues(left[i].value, right[i].value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// equalMembers compares the members of two objects of the same size, regardless of their order.
+func (e *evaluator) equalMembers(left, right []member) bool {
+	index := make(map[string]any, len(right))
+	for _, m := range right {
+		index[m.token] = m.value
+	}
+
+	for _, m := range left {
+		r, ok := index[m.token]
+		if !ok || !e.equalValues(m.value, r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// less tells if the left value is less than the right value. Only numbers and strings are ordered.
+func less(left any, hasLeft bool, right any, hasRight bool) bool {
+	if !hasLeft || !hasRight {
+		return false
+	}
+
+	switch l := normalize(left).(type) {
+	case float64:
+		r, ok := normalize(right).(float64)
+
+		return ok && l < r
+	case string:
+		r, ok := normalize(right).(string)
+
+		return ok && l < r
+	default:
+		return false
+	}
+}
+
+// normalize converts go values to the JSON values they represent, for comparison.
+//
+// Pointers are dereferenced, numbers are converted to float64, and named string or boolean types to
+// their underlying type. A nil pointer, map or slice is a JSON null.
+func normalize(value any) any {
+	rv := indirect(value)
+	if !rv.IsValid() {
+		return nil
+	}
+
+	if rv.Type() == jsonNumberType {
+		f, err := strconv.ParseFloat(rv.String(), 64)
+		if err != nil {
+			return nil
+		}
+
+		return f
+	}
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	case reflect.String:
+		return rv.String()
+	case reflect.Bool:
+		return rv.Bool()
+	case reflect.Map, reflect.Slice:
+		if rv.IsNil() {
+			return nil
+		}
+
+		return rv.Interface()
+	default:
+		return rv.Interface()
+	}
+}
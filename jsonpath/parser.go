@@ -0,0 +1,731 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpath
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// maxSafeInteger is the largest integer that may be used as an index or a slice bound, as specified
+// by RFC 9535 (the I-JSON range).
+const maxSafeInteger = 1<<53 - 1
+
+// parser is a recursive descent parser for the RFC 9535 grammar.
+type parser struct {
+	expr string
+	pos  int
+}
+
+// parseQuery parses a jsonpath-query: a root identifier followed by segments.
+func (p *parser) parseQuery() ([]segment, error) {
+	if !p.consume('$') {
+		return nil, p.errorf(`expected "$"`)
+	}
+
+	segments, err := p.parseSegments()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.eof() {
+		return nil, p.errorf("unexpected character")
+	}
+
+	return segments, nil
+}
+
+func (p *parser) parseSegments() ([]segment, error) {
+	var segments []segment
+
+	for {
+		start := p.pos
+		p.skipBlank()
+
+		if p.eof() || (p.peek() != '.' && p.peek() != '[') {
+			// blank space is only allowed between segments
+			p.pos = start
+
+			return segments, nil
+		}
+
+		seg, err := p.parseSegment()
+		if err != nil {
+			return nil, err
+		}
+
+		segments = append(segments, seg)
+	}
+}
+
+func (p *parser) parseSegment() (segment, error) {
+	descendant := strings.HasPrefix(p.expr[p.pos:], "..")
+
+	switch {
+	case descendant:
+		p.pos += 2
+
+		if p.peek() == '[' {
+			selectors, err := p.parseBracketedSelection()
+
+			return segment{descendant: true, selectors: selectors}, err
+		}
+	case p.peek() == '.':
+		p.pos++
+	default:
+		selectors, err := p.parseBracketedSelection()
+
+		return segment{selectors: selectors}, err
+	}
+
+	if p.consume('*') {
+		return segment{descendant: descendant, selectors: []selector{wildcardSelector{}}}, nil
+	}
+
+	name, err := p.parseMemberName()
+	if err != nil {
+		return segment{}, err
+	}
+
+	return segment{descendant: descendant, selectors: []selector{nameSelector{name: name}}}, nil
+}
+
+func (p *parser) parseBracketedSelection() ([]selector, error) {
+	p.pos++ // "["
+
+	var selectors []selector
+
+	for {
+		p.skipBlank()
+
+		sel, err := p.parseSelector()
+		if err != nil {
+			return nil, err
+		}
+
+		selectors = append(selectors, sel)
+
+		p.skipBlank()
+
+		switch {
+		case p.consume(','):
+		case p.consume(']'):
+			return selectors, nil
+		default:
+			return nil, p.errorf(`expected "," or "]"`)
+		}
+	}
+}
+
+func (p *parser) parseSelector() (selector, error) {
+	switch c := p.peek(); {
+	case c == '\'' || c == '"':
+		name, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+
+		return nameSelector{name: name}, nil
+
+	case c == '*':
+		p.pos++
+
+		return wildcardSelector{}, nil
+
+	case c == '?':
+		p.pos++
+		p.skipBlank()
+
+		expr, err := p.parseLogicalOr()
+		if err != nil {
+			return nil, err
+		}
+
+		return filterSelector{expr: expr}, nil
+
+	case c == '-' || c == ':' || isDigit(c):
+		return p.parseIndexOrSlice()
+
+	default:
+		return nil, p.errorf("expected a selector")
+	}
+}
+
+// parseIndexOrSlice parses an index selector, e.g. "-1", or a slice selector, e.g. "1:5:2".
+func (p *parser) parseIndexOrSlice() (selector, error) {
+	var (
+		start    int
+		hasStart bool
+		err      error
+	)
+
+	if p.peek() != ':' {
+		start, err = p.parseInt()
+		if err != nil {
+			return nil, err
+		}
+		hasStart = true
+	}
+
+	afterStart := p.pos
+	p.skipBlank()
+
+	if !p.consume(':') {
+		p.pos = afterStart
+
+		return indexSelector{index: start}, nil
+	}
+
+	slice := sliceSelector{start: start, hasStart: hasStart, step: 1}
+
+	p.skipBlank()
+	if p.peek() == '-' || isDigit(p.peek()) {
+		if slice.end, err = p.parseInt(); err != nil {
+			return nil, err
+		}
+		slice.hasEnd = true
+
+		p.skipBlank()
+	}
+
+	if p.consume(':') {
+		p.skipBlank()
+		if p.peek() == '-' || isDigit(p.peek()) {
+			if slice.step, err = p.parseInt(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return slice, nil
+}
+
+// parseInt parses an integer without leading zeros, in the I-JSON range.
+func (p *parser) parseInt() (int, error) {
+	start := p.pos
+	p.consume('-')
+
+	switch {
+	case p.eof() || !isDigit(p.peek()):
+		return 0, p.errorf("expected an integer")
+	case p.peek() == '0':
+		p.pos++
+		if p.pos-start > 1 || isDigit(p.peek()) {
+			return 0, p.errorAt(start, "invalid integer")
+		}
+	default:
+		p.skipDigits()
+	}
+
+	n, err := strconv.ParseInt(p.expr[start:p.pos], 10, 64)
+	if err != nil || n > maxSafeInteger || n < -maxSafeInteger {
+		return 0, p.errorAt(start, "integer out of range")
+	}
+
+	return int(n), nil
+}
+
+// parseMemberName parses the member name of a shorthand segment, e.g. ".name".
+func (p *parser) parseMemberName() (string, error) {
+	start := p.pos
+
+	for !p.eof() {
+		r, size := utf8.DecodeRuneInString(p.expr[p.pos:])
+		if !isNameFirst(r, size) && (p.pos == start || !isDigit(p.peek())) {
+			break
+		}
+
+		p.pos += size
+	}
+
+	if p.pos == start {
+		return "", p.errorf("expected a member name")
+	}
+
+	return p.expr[start:p.pos], nil
+}
+
+// parseString parses a single-quoted or double-quoted string literal.
+func (p *parser) parseString() (string, error) {
+	start := p.pos
+	quote := p.expr[p.pos]
+	p.pos++
+
+	var b strings.Builder
+
+	for {
+		if p.eof() {
+			return "", p.errorAt(start, "unterminated string")
+		}
+
+		c := p.expr[p.pos]
+
+		switch {
+		case c == quote:
+			p.pos++
+
+			return b.String(), nil
+
+		case c == '\\':
+			if err := p.parseEscape(quote, &b); err != nil {
+				return "", err
+			}
+
+		case c < 0x20:
+			return "", p.errorf("control character in string")
+
+		default:
+			r, size := utf8.DecodeRuneInString(p.expr[p.pos:])
+			if r == utf8.RuneError && size == 1 {
+				return "", p.errorf("invalid UTF-8 in string")
+			}
+
+			b.WriteString(p.expr[p.pos : p.pos+size])
+			p.pos += size
+		}
+	}
+}
+
+func (p *parser) parseEscape(quote byte, b *strings.Builder) error {
+	start := p.pos
+	p.pos++ // "\"
+
+	if p.eof() {
+		return p.errorAt(start, "invalid escape sequence")
+	}
+
+	c := p.expr[p.pos]
+	p.pos++
+
+	switch c {
+	case 'b':
+		b.WriteByte('\b')
+	case 'f':
+		b.WriteByte('\f')
+	case 'n':
+		b.WriteByte('\n')
+	case 'r':
+		b.WriteByte('\r')
+	case 't':
+		b.WriteByte('\t')
+	case '/', '\\':
+		b.WriteByte(c)
+	case '\'', '"':
+		// only the enclosing quote may be escaped
+		if c != quote {
+			return p.errorAt(start, "invalid escape sequence")
+		}
+		b.WriteByte(c)
+	case 'u':
+		r, err := p.parseUnicodeEscape(start)
+		if err != nil {
+			return err
+		}
+		b.WriteRune(r)
+	default:
+		return p.errorAt(start, "invalid escape sequence")
+	}
+
+	return nil
+}
+
+// parseUnicodeEscape parses the hexadecimal digits of a "\uXXXX" escape sequence, including a
+// trailing low surrogate.
+func (p *parser) parseUnicodeEscape(start int) (rune, error) {
+	r, ok := p.parseHex4()
+	if !ok {
+		return 0, p.errorAt(start, "invalid unicode escape sequence")
+	}
+
+	switch {
+	case utf16.IsSurrogate(r) && r < 0xDC00:
+		// high surrogate: must be followed by a low surrogate
+		if !strings.HasPrefix(p.expr[p.pos:], `\u`) {
+			return 0, p.errorAt(start, "unpaired surrogate in unicode escape sequence")
+		}
+		p.pos += 2
+
+		low, ok := p.parseHex4()
+		if !ok || low < 0xDC00 || low > 0xDFFF {
+			return 0, p.errorAt(start, "unpaired surrogate in unicode escape sequence")
+		}
+
+		return utf16.DecodeRune(r, low), nil
+
+	case utf16.IsSurrogate(r):
+		return 0, p.errorAt(start, "unpaired surrogate in unicode escape sequence")
+
+	default:
+		return r, nil
+	}
+}
+
+func (p *parser) parseHex4() (rune, bool) {
+	const hexLen = 4
+
+	if len(p.expr)-p.pos < hexLen {
+		return 0, false
+	}
+
+	n, err := strconv.ParseUint(p.expr[p.pos:p.pos+hexLen], 16, 32)
+	if err != nil {
+		return 0, false
+	}
+	p.pos += hexLen
+
+	return rune(n), true
+}
+
+func (p *parser) parseLogicalOr() (logicalExpr, error) {
+	return p.parseLogicalSequence("||", p.parseLogicalAnd, func(exprs []logicalExpr) logicalExpr { return orExpr(exprs) })
+}
+
+func (p *parser) parseLogicalAnd() (logicalExpr, error) {
+	return p.parseLogicalSequence("&&", p.parseBasicExpr, func(exprs []logicalExpr) logicalExpr { return andExpr(exprs) })
+}
+
+// parseLogicalSequence parses operands separated by a logical operator.
+func (p *parser) parseLogicalSequence(
+	operator string,
+	parseOperand func() (logicalExpr, error),
+	combine func([]logicalExpr) logicalExpr,
+) (logicalExpr, error) {
+	expr, err := parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	exprs := []logicalExpr{expr}
+
+	for {
+		start := p.pos
+		p.skipBlank()
+
+		if !strings.HasPrefix(p.expr[p.pos:], operator) {
+			p.pos = start
+
+			break
+		}
+
+		p.pos += len(operator)
+		p.skipBlank()
+
+		expr, err := parseOperand()
+		if err != nil {
+			return nil, err
+		}
+
+		exprs = append(exprs, expr)
+	}
+
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+
+	return combine(exprs), nil
+}
+
+// parseBasicExpr parses a parenthesized expression, a comparison or an existence test.
+func (p *parser) parseBasicExpr() (logicalExpr, error) {
+	if p.consume('!') {
+		p.skipBlank()
+
+		if p.peek() == '(' {
+			expr, err := p.parseParenExpr()
+			if err != nil {
+				return nil, err
+			}
+
+			return notExpr{expr: expr}, nil
+		}
+
+		if c := p.peek(); c != '@' && c != '$' {
+			if err := p.checkFunction(); err != nil {
+				return nil, err
+			}
+
+			return nil, p.errorf(`expected "(" or a query`)
+		}
+
+		query, err := p.parseFilterQuery()
+		if err != nil {
+			return nil, err
+		}
+
+		return notExpr{expr: existExpr{query: query}}, nil
+	}
+
+	if p.peek() == '(' {
+		return p.parseParenExpr()
+	}
+
+	start := p.pos
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	afterLeft := p.pos
+	p.skipBlank()
+
+	op, ok := p.parseComparisonOp()
+	if !ok {
+		p.pos = afterLeft
+
+		if query, isQuery := left.(filterQuery); isQuery {
+			return existExpr{query: query}, nil
+		}
+
+		return nil, p.errorAt(start, "a literal must be compared")
+	}
+
+	p.skipBlank()
+
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, operand := range []operand{left, right} {
+		if query, isQuery := operand.(filterQuery); isQuery && !query.isSingular() {
+			return nil, p.errorAt(start, "a query compared to a value must be a singular query")
+		}
+	}
+
+	return comparisonExpr{op: op, left: left, right: right}, nil
+}
+
+func (p *parser) parseParenExpr() (logicalExpr, error) {
+	p.pos++ // "("
+	p.skipBlank()
+
+	expr, err := p.parseLogicalOr()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipBlank()
+	if !p.consume(')') {
+		return nil, p.errorf(`expected ")"`)
+	}
+
+	return expr, nil
+}
+
+func (p *parser) parseComparisonOp() (comparisonOp, bool) {
+	for _, candidate := range []struct {
+		token string
+		op    comparisonOp
+	}{
+		// longest tokens first
+		{token: "==", op: opEqual},
+		{token: "!=", op: opNotEqual},
+		{token: "<=", op: opLessOrEqual},
+		{token: ">=", op: opGreaterOrEqual},
+		{token: "<", op: opLess},
+		{token: ">", op: opGreater},
+	} {
+		if strings.HasPrefix(p.expr[p.pos:], candidate.token) {
+			p.pos += len(candidate.token)
+
+			return candidate.op, true
+		}
+	}
+
+	return 0, false
+}
+
+// parseOperand parses a literal or a query.
+func (p *parser) parseOperand() (operand, error) {
+	switch c := p.peek(); {
+	case c == '@' || c == '$':
+		return p.parseFilterQuery()
+
+	case c == '\'' || c == '"':
+		s, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+
+		return literal{v: s}, nil
+
+	case c == '-' || isDigit(c):
+		return p.parseNumber()
+	}
+
+	for _, keyword := range []struct {
+		token string
+		value any
+	}{
+		{token: "true", value: true},
+		{token: "false", value: false},
+		{token: "null", value: nil},
+	} {
+		if p.consumeKeyword(keyword.token) {
+			return literal{v: keyword.value}, nil
+		}
+	}
+
+	if err := p.checkFunction(); err != nil {
+		return nil, err
+	}
+
+	return nil, p.errorf("expected a literal or a query")
+}
+
+func (p *parser) parseFilterQuery() (filterQuery, error) {
+	relative := p.expr[p.pos] == '@'
+	p.pos++
+
+	segments, err := p.parseSegments()
+	if err != nil {
+		return filterQuery{}, err
+	}
+
+	return filterQuery{relative: relative, segments: segments}, nil
+}
+
+// parseNumber parses a number literal, with the JSON syntax.
+func (p *parser) parseNumber() (operand, error) {
+	start := p.pos
+	p.consume('-')
+
+	switch {
+	case p.eof() || !isDigit(p.peek()):
+		return nil, p.errorf("expected a number")
+	case p.peek() == '0':
+		p.pos++
+	default:
+		p.skipDigits()
+	}
+
+	if p.consume('.') {
+		if p.eof() || !isDigit(p.peek()) {
+			return nil, p.errorf("expected a digit")
+		}
+		p.skipDigits()
+	}
+
+	if p.consume('e') || p.consume('E') {
+		if !p.consume('+') {
+			p.consume('-')
+		}
+
+		if p.eof() || !isDigit(p.peek()) {
+			return nil, p.errorf("expected a digit")
+		}
+		p.skipDigits()
+	}
+
+	f, err := strconv.ParseFloat(p.expr[start:p.pos], 64)
+	if err != nil {
+		return nil, p.errorAt(start, "invalid number")
+	}
+
+	return literal{v: f}, nil
+}
+
+// checkFunction reports function extensions, which are not supported.
+func (p *parser) checkFunction() error {
+	end := p.pos
+	for end < len(p.expr) && (isLowerAlpha(p.expr[end]) || (end > p.pos && (p.expr[end] == '_' || isDigit(p.expr[end])))) {
+		end++
+	}
+
+	if end > p.pos && end < len(p.expr) && p.expr[end] == '(' {
+		return errUnsupportedFunction(p.expr, p.pos, p.expr[p.pos:end])
+	}
+
+	return nil
+}
+
+func (p *parser) consumeKeyword(keyword string) bool {
+	if !strings.HasPrefix(p.expr[p.pos:], keyword) {
+		return false
+	}
+
+	end := p.pos + len(keyword)
+	if end < len(p.expr) {
+		r, size := utf8.DecodeRuneInString(p.expr[end:])
+		if isNameFirst(r, size) || isDigit(p.expr[end]) {
+			return false
+		}
+	}
+
+	p.pos = end
+
+	return true
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.expr)
+}
+
+// peek returns the current byte, or 0 at the end of the expression.
+func (p *parser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+
+	return p.expr[p.pos]
+}
+
+func (p *parser) consume(c byte) bool {
+	if p.eof() || p.expr[p.pos] != c {
+		return false
+	}
+
+	p.pos++
+
+	return true
+}
+
+func (p *parser) skipBlank() {
+	for !p.eof() {
+		switch p.expr[p.pos] {
+		case ' ', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *parser) skipDigits() {
+	for !p.eof() && isDigit(p.expr[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *parser) errorf(msg string) error {
+	return errSyntax(p.expr, p.pos, msg)
+}
+
+func (p *parser) errorAt(pos int, msg string) error {
+	return errSyntax(p.expr, pos, msg)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLowerAlpha(c byte) bool {
+	return c >= 'a' && c <= 'z'
+}
+
+// isNameFirst tells if a rune may start a member name shorthand.
+func isNameFirst(r rune, size int) bool {
+	switch {
+	case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		return true
+	case r == utf8.RuneError && size == 1:
+		// invalid UTF-8
+		return false
+	default:
+		return r >= 0x80
+	}
+}
@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpath
+
+import (
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("with valid queries", func(t *testing.T) {
+		t.Parallel()
+
+		for _, expr := range []string{
+			"$",
+			"$.a",
+			"$.a_b1",
+			"$._",
+			"$.é",
+			"$.*",
+			"$..a",
+			"$..*",
+			"$..[0]",
+			"$[*]",
+			"$['a', \"b\", 0, -1, 1:2, ::-1, *]",
+			"$ .a [0]",
+			"$[ 0 ]",
+			"$[:]",
+			"$[::]",
+			"$[?@]",
+			"$[?@.a]",
+			"$[?$.a]",
+			"$[?!@.a]",
+			"$[?(@.a)]",
+			"$[?!(@.a == 1)]",
+			"$[? @.a == 1 ]",
+			"$[?@.a==1&&@.b!=2||@.c<3]",
+			"$[?@.a == 'x' && (@.b >= 1.5e3 || @.c <= -2)]",
+			"$[?@.a == true || @.a == false || @.a == null]",
+			"$[?@['a'][0] == $.b]",
+			"$[?@[?@.a]]",
+			"$[?@..a]",
+			"$[9007199254740991]",
+			"$[-9007199254740991]",
+			`$["😀"]`,
+		} {
+			t.Run(expr, func(t *testing.T) {
+				t.Parallel()
+
+				p, err := Parse(expr)
+				require.NoError(t, err)
+				assert.EqualT(t, expr, p.String())
+			})
+		}
+	})
+
+	t.Run("with invalid queries", func(t *testing.T) {
+		t.Parallel()
+
+		for _, expr := range []string{
+			"",
+			"a",
+			" $",
+			"$ ",
+			"$.",
+			"$..",
+			"$.1a",
+			"$. a",
+			"$.. a",
+			"$[",
+			"$[]",
+			"$[0",
+			"$[0,]",
+			"$['a]",
+			`$["a\'"]`,
+			`$['a\"']`,
+			`$['a\x']`,
+			"$['a\x01']",
+			`$["\ud83d"]`,
+			`$["\ude00"]`,
+			`$["\u12"]`,
+			"$[01]",
+			"$[-0]",
+			"$[+1]",
+			"$[9007199254740992]",
+			"$[1:2:3:4]",
+			"$[?]",
+			"$[?1]",
+			"$[?'a']",
+			"$[?true]",
+			"$[?@.a ==]",
+			"$[?@.a = 1]",
+			"$[?(@.a]",
+			"$[?@.* == 1]",
+			"$[?@..a == 1]",
+			"$[?@[0, 1] == 1]",
+			"$[?@.a == 01]",
+			"$[?@.a == 1.]",
+			"$[?@.a == 1e]",
+			"$[?@.a == truex]",
+			"$[?!1]",
+			"$[?@.a == [1]]", // no array literal
+		} {
+			t.Run(expr, func(t *testing.T) {
+				t.Parallel()
+
+				_, err := Parse(expr)
+				require.Error(t, err)
+				require.ErrorIs(t, err, ErrPath)
+			})
+		}
+	})
+
+	t.Run("with function extensions", func(t *testing.T) {
+		t.Parallel()
+
+		for _, expr := range []string{
+			"$[?length(@.a) > 1]",
+			"$[?match(@.a, 'x')]",
+			"$[?!search(@.a, 'x')]",
+		} {
+			t.Run(expr, func(t *testing.T) {
+				t.Parallel()
+
+				_, err := Parse(expr)
+				require.ErrorIs(t, err, ErrUnsupported)
+				require.ErrorIs(t, err, ErrPath)
+			})
+		}
+	})
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpath
+
+import (
+	"iter"
+
+	"github.com/go-openapi/jsonpointer"
+)
+
+// Path is a parsed JSONPath query.
+//
+// A Path is immutable and may be safely shared between goroutines.
+type Path struct {
+	expr     string
+	segments []segment
+}
+
+// Match is a value selected by a JSONPath query, along with its location in the document.
+type Match struct {
+	// Pointer to the selected value.
+	Pointer jsonpointer.Pointer
+
+	// Path is the RFC 9535 normalized path of the selected value, e.g. $['store']['book'][0].
+	Path string
+
+	// Value is the selected value.
+	Value any
+}
+
+// Parse a JSONPath query.
+//
+// It fails with an error wrapping [ErrPath] if the query is not valid, or uses a feature which is
+// not supported (see [ErrUnsupported]).
+func Parse(expr string) (Path, error) {
+	p := parser{expr: expr}
+
+	segments, err := p.parseQuery()
+	if err != nil {
+		return Path{}, err
+	}
+
+	return Path{expr: expr, segments: segments}, nil
+}
+
+// String returns the JSONPath query as it was parsed.
+func (p Path) String() string {
+	return p.expr
+}
+
+// Query evaluates the JSONPath query against a document, and returns the selected values in order.
+//
+// Options tune how the document is traversed, like for [jsonpointer.Pointer.Get]:
+//
+//   - [jsonpointer.WithNameProvider] resolves the json names of go struct fields
+//   - [jsonpointer.WithSortedKeys] selects the members of objects in a deterministic order, e.g. with
+//     a wildcard selector. By default, the members of go maps are selected in no particular order
+//
+// Other options have no effect.
+func (p Path) Query(document any, opts ...jsonpointer.Option) []Match {
+	e := newEvaluator(document, opts)
+	nodes := e.query(e.root, p.segments)
+
+	matches := make([]Match, 0, len(nodes))
+	for _, n := range nodes {
+		matches = append(matches, Match{Pointer: n.pointer, Path: n.path, Value: n.value})
+	}
+
+	return matches
+}
+
+// Find iterates over the values selected by the JSONPath query, like [Path.Query], yielding their
+// pointer and value.
+func (p Path) Find(document any, opts ...jsonpointer.Option) iter.Seq2[jsonpointer.Pointer, any] {
+	return func(yield func(jsonpointer.Pointer, any) bool) {
+		for _, match := range p.Query(document, opts...) {
+			if !yield(match.Pointer, match.Value) {
+				return
+			}
+		}
+	}
+}
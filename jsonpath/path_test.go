@@ -0,0 +1,370 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpath
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/go-openapi/jsonpointer"
+	"github.com/go-openapi/jsonpointer/jsonname"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+// testStoreJSON is the example document of RFC 9535, section 1.5.
+const testStoreJSON = `{
+  "store": {
+    "book": [
+      {
+        "category": "reference",
+        "author": "Nigel Rees",
+        "title": "Sayings of the Century",
+        "price": 8.95
+      },
+      {
+        "category": "fiction",
+        "author": "Evelyn Waugh",
+        "title": "Sword of Honour",
+        "price": 12.99
+      },
+      {
+        "category": "fiction",
+        "author": "Herman Melville",
+        "title": "Moby Dick",
+        "isbn": "0-553-21311-3",
+        "price": 8.99
+      },
+      {
+        "category": "fiction",
+        "author": "J. R. R. Tolkien",
+        "title": "The Lord of the Rings",
+        "isbn": "0-395-19395-8",
+        "price": 22.99
+      }
+    ],
+    "bicycle": {
+      "color": "red",
+      "price": 399
+    }
+  }
+}`
+
+func testJSON(t *testing.T, doc string) any {
+	t.Helper()
+
+	var document any
+	require.NoError(t, json.Unmarshal([]byte(doc), &document))
+
+	return document
+}
+
+func mustParse(t *testing.T, expr string) Path {
+	t.Helper()
+
+	p, err := Parse(expr)
+	require.NoError(t, err)
+
+	return p
+}
+
+// matchedPaths returns the normalized paths of the values selected by a query.
+func matchedPaths(t *testing.T, expr string, document any, opts ...jsonpointer.Option) []string {
+	t.Helper()
+
+	var paths []string
+	for _, match := range mustParse(t, expr).Query(document, opts...) {
+		paths = append(paths, match.Path)
+	}
+
+	return paths
+}
+
+func TestPath_Query(t *testing.T) {
+	t.Parallel()
+
+	doc := testJSON(t, testStoreJSON)
+
+	for _, tc := range []struct {
+		expr     string
+		expected []string
+	}{
+		{expr: "$", expected: []string{"$"}},
+		{expr: "$.store.book[*].author", expected: []string{
+			"$['store']['book'][0]['author']",
+			"$['store']['book'][1]['author']",
+			"$['store']['book'][2]['author']",
+			"$['store']['book'][3]['author']",
+		}},
+		{expr: "$..author", expected: []string{
+			"$['store']['book'][0]['author']",
+			"$['store']['book'][1]['author']",
+			"$['store']['book'][2]['author']",
+			"$['store']['book'][3]['author']",
+		}},
+		{expr: "$.store.*", expected: []string{"$['store']['bicycle']", "$['store']['book']"}},
+		{expr: "$.store..price", expected: []string{
+			"$['store']['bicycle']['price']",
+			"$['store']['book'][0]['price']",
+			"$['store']['book'][1]['price']",
+			"$['store']['book'][2]['price']",
+			"$['store']['book'][3]['price']",
+		}},
+		{expr: "$..book[2]", expected: []string{"$['store']['book'][2]"}},
+		{expr: "$..book[-1]", expected: []string{"$['store']['book'][3]"}},
+		{expr: "$..book[0,1]", expected: []string{"$['store']['book'][0]", "$['store']['book'][1]"}},
+		{expr: "$..book[:2]", expected: []string{"$['store']['book'][0]", "$['store']['book'][1]"}},
+		{expr: "$..book[?@.isbn]", expected: []string{"$['store']['book'][2]", "$['store']['book'][3]"}},
+		{expr: "$..book[?@.price<10]", expected: []string{"$['store']['book'][0]", "$['store']['book'][2]"}},
+		{expr: `$["store"]['book'][?(@.category == "fiction" && !@.isbn)].title`, expected: []string{
+			"$['store']['book'][1]['title']",
+		}},
+		{expr: `$.store.book[?@.price > $.store.bicycle.price || @.author == 'Nigel Rees'].title`, expected: []string{
+			"$['store']['book'][0]['title']",
+		}},
+		{expr: "$.store.book[?@.price >= 22.99 ].price", expected: []string{"$['store']['book'][3]['price']"}},
+		{expr: "$.store.book[?@.price <= 8.95].price", expected: []string{"$['store']['book'][0]['price']"}},
+		{expr: "$.store.book[?@.price != 8.95].price", expected: []string{
+			"$['store']['book'][1]['price']",
+			"$['store']['book'][2]['price']",
+			"$['store']['book'][3]['price']",
+		}},
+		{expr: "$.store.missing", expected: nil},
+		{expr: "$.store.book.title", expected: nil},
+		{expr: "$.store.bicycle[0]", expected: nil},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, matchedPaths(t, tc.expr, doc, jsonpointer.WithSortedKeys()))
+		})
+	}
+
+	t.Run("matches are resolved by their pointer", func(t *testing.T) {
+		t.Parallel()
+
+		matches := mustParse(t, "$..*").Query(doc)
+		assert.Len(t, matches, 27)
+
+		for _, match := range matches {
+			value, _, err := match.Pointer.Get(doc)
+			require.NoError(t, err)
+			assert.Equal(t, value, match.Value)
+		}
+	})
+}
+
+func TestPath_Query_slices(t *testing.T) {
+	t.Parallel()
+
+	doc := testJSON(t, `["a", "b", "c", "d", "e", "f", "g"]`)
+
+	values := func(expr string) []any {
+		var values []any
+		for _, value := range mustParse(t, expr).Find(doc) {
+			values = append(values, value)
+		}
+
+		return values
+	}
+
+	for _, tc := range []struct {
+		expr     string
+		expected []any
+	}{
+		{expr: "$[1:3]", expected: []any{"b", "c"}},
+		{expr: "$[5:]", expected: []any{"f", "g"}},
+		{expr: "$[1:5:2]", expected: []any{"b", "d"}},
+		{expr: "$[5:1:-2]", expected: []any{"f", "d"}},
+		{expr: "$[::-1]", expected: []any{"g", "f", "e", "d", "c", "b", "a"}},
+		{expr: "$[-2:]", expected: []any{"f", "g"}},
+		{expr: "$[ 1 : 3 : 1 ]", expected: []any{"b", "c"}},
+		{expr: "$[::0]", expected: nil},
+		{expr: "$[10:20]", expected: nil},
+		{expr: "$[0, 0]", expected: []any{"a", "a"}},
+		{expr: "$[-8]", expected: nil},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			assert.Equal(t, tc.expected, values(tc.expr))
+		})
+	}
+}
+
+func TestPath_Query_comparisons(t *testing.T) {
+	t.Parallel()
+
+	doc := testJSON(t, `{
+		"items": [
+			{"id": 0, "v": null},
+			{"id": 1, "v": [1, 2]},
+			{"id": 2, "v": {"a": "b"}},
+			{"id": 3, "v": true},
+			{"id": 4, "v": "abc"},
+			{"id": 5}
+		]
+	}`)
+
+	ids := func(filter string) []any {
+		var ids []any
+		for _, value := range mustParse(t, "$.items[?"+filter+"].id").Find(doc) {
+			ids = append(ids, value)
+		}
+
+		return ids
+	}
+
+	for _, tc := range []struct {
+		filter   string
+		expected []any
+	}{
+		{filter: "@.v == null", expected: []any{0.0}},
+		{filter: "@.v == $.items[1].v", expected: []any{1.0}},
+		{filter: "@.v == $.items[2].v", expected: []any{2.0}},
+		{filter: "@.v == true", expected: []any{3.0}},
+		{filter: "@.v > 'abb'", expected: []any{4.0}},
+		{filter: "@.v == @.missing", expected: []any{5.0}},
+		{filter: "@.v != null", expected: []any{1.0, 2.0, 3.0, 4.0, 5.0}},
+		{filter: "@.v < true", expected: nil},
+		{filter: "@.v <= @.missing", expected: []any{5.0}},
+		{filter: "@.id == 1.0", expected: []any{1.0}},
+		{filter: "@.id == 1e0", expected: []any{1.0}},
+		{filter: "@.id == -0", expected: []any{0.0}},
+		{filter: "!(@.id > 1)", expected: []any{0.0, 1.0}},
+		{filter: "@.id < 1 || @.id > 4", expected: []any{0.0, 5.0}},
+		{filter: "@.id > 1 && @.id < 4 && @.v", expected: []any{2.0, 3.0}},
+		{filter: "@..a", expected: []any{2.0}},
+		{filter: "$.items[?@.id == 3]", expected: []any{0.0, 1.0, 2.0, 3.0, 4.0, 5.0}},
+	} {
+		t.Run(tc.filter, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ids(tc.filter))
+		})
+	}
+}
+
+func TestPath_Query_goValues(t *testing.T) {
+	t.Parallel()
+
+	type Book struct {
+		Title  string   `json:"title"`
+		Price  float32  `json:"price"`
+		Rating *int     `json:"rating,omitempty"`
+		Tags   []string `json:"tags"`
+		Secret string
+	}
+	type Store struct {
+		Books   []*Book        `json:"books"`
+		Ratings map[string]int `json:"ratings"`
+	}
+
+	five := 5
+	doc := &Store{
+		Books: []*Book{
+			{Title: "a", Price: 10, Rating: &five, Tags: []string{"x"}, Secret: "s"},
+			{Title: "b", Price: 5.5},
+		},
+		Ratings: map[string]int{"a": 5},
+	}
+
+	t.Run("with struct fields named by the name provider", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t,
+			[]string{"$['books'][0]['title']", "$['books'][0]['price']", "$['books'][0]['rating']", "$['books'][0]['tags']"},
+			matchedPaths(t, "$.books[0].*", doc),
+		)
+		assert.Empty(t, matchedPaths(t, "$.books[0].Secret", doc))
+		assert.Equal(t,
+			[]string{"$['books'][0]['Secret']"},
+			matchedPaths(t, "$.books[0].Secret", doc, jsonpointer.WithNameProvider(jsonname.NewGoNameProvider())),
+		)
+	})
+
+	t.Run("with comparisons of go values", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []string{"$['books'][1]"}, matchedPaths(t, "$.books[?@.price < 6]", doc))
+		assert.Equal(t, []string{"$['books'][0]"}, matchedPaths(t, "$.books[?@.rating == $.ratings.a]", doc))
+		assert.Equal(t, []string{"$['books'][1]"}, matchedPaths(t, "$.books[?@.tags == null]", doc))
+	})
+
+	t.Run("with JSONPointable", func(t *testing.T) {
+		t.Parallel()
+
+		doc := map[string]any{"p": pointable{"key": "value"}}
+
+		matches := mustParse(t, "$.p.key").Query(doc)
+		require.Len(t, matches, 1)
+		assert.Equal(t, "lookup:value", matches[0].Value)
+		assert.Equal(t, "/p/key", matches[0].Pointer.String())
+	})
+
+	t.Run("with cycles", func(t *testing.T) {
+		t.Parallel()
+
+		type Node struct {
+			Name string `json:"name"`
+			Next *Node  `json:"next"`
+		}
+
+		a := &Node{Name: "a"}
+		a.Next = &Node{Name: "b", Next: a}
+
+		assert.Equal(t,
+			[]string{"$['name']", "$['next']['name']", "$['next']['next']['name']"},
+			matchedPaths(t, "$..name", a),
+		)
+	})
+}
+
+func TestPath_Query_names(t *testing.T) {
+	t.Parallel()
+
+	doc := map[string]any{
+		"a'b":   1,
+		"a\\b":  2,
+		"a\nb":  3,
+		"a\x01": 4,
+		"é":     5,
+		"":      6,
+		"a/b~c": 7,
+	}
+
+	for _, tc := range []struct {
+		expr    string
+		path    string
+		pointer string
+	}{
+		{expr: `$['a\'b']`, path: `$['a\'b']`, pointer: "/a'b"},
+		{expr: `$["a'b"]`, path: `$['a\'b']`, pointer: "/a'b"},
+		{expr: `$['a\\b']`, path: `$['a\\b']`, pointer: `/a\b`},
+		{expr: `$["a\nb"]`, path: `$['a\nb']`, pointer: "/a\nb"},
+		{expr: `$["a\u0001"]`, path: `$['a\u0001']`, pointer: "/a\x01"},
+		{expr: `$.é`, path: `$['é']`, pointer: "/é"},
+		{expr: `$["é"]`, path: `$['é']`, pointer: "/é"},
+		{expr: `$['']`, path: `$['']`, pointer: "/"},
+		{expr: `$['a/b~c']`, path: `$['a/b~c']`, pointer: "/a~1b~0c"},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			t.Parallel()
+
+			matches := mustParse(t, tc.expr).Query(doc)
+			require.Len(t, matches, 1)
+			assert.Equal(t, tc.path, matches[0].Path)
+			assert.Equal(t, tc.pointer, matches[0].Pointer.String())
+		})
+	}
+}
+
+// pointable is an object that resolves its keys with JSONLookup.
+type pointable map[string]string
+
+func (p pointable) JSONLookup(key string) (any, error) {
+	v, ok := p[key]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+
+	return "lookup:" + v, nil
+}
@@ -0,0 +1,206 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonref
+
+import (
+	"errors"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/jsonpointer"
+)
+
+// defsKey is the member of the bundled document that holds the values referenced in other documents.
+const defsKey = "$defs"
+
+// Bundle gathers a document and the documents it references into a single document, with local
+// references only.
+//
+// The root document and the documents it references, directly or not, are loaded with the [Loader].
+// The values referenced in other documents are copied under the "$defs" member of the root
+// document, and the references to these values are replaced by local references, e.g.
+// "models/pet.json#/Pet" becomes "#/$defs/Pet". Chained references are followed like by
+// [Resolver.Resolve], so that references target the final value.
+//
+// A value referenced several times is copied once, and all the references to this value share the
+// same local reference. Since values are not inlined, recursive values are supported.
+//
+// The bundled document is a copy: documents are not modified. References that cannot be resolved
+// are left unchanged, and reported by an error that joins one error per reference, each mentioning
+// the location of the reference in its document.
+func Bundle(root string, loader Loader) (any, error) {
+	r := NewResolver(loader)
+
+	document, err := r.Document(root)
+	if err != nil {
+		return nil, err
+	}
+
+	b := bundler{
+		resolver: r,
+		root:     root,
+		document: copyJSON(document),
+		names:    make(map[string]string),
+	}
+
+	return b.document, b.bundle()
+}
+
+// bundler gathers referenced values into a root document.
+type bundler struct {
+	resolver *Resolver
+	root     string
+	document any
+	defs     map[string]any
+	names    map[string]string // names of the gathered values, by location
+	queue    []bundled
+}
+
+// bundled is a value of the bundled document, along with its location in its original document.
+type bundled struct {
+	at   Ref
+	node any
+}
+
+func (b *bundler) bundle() error {
+	var errs []error
+
+	b.queue = append(b.queue, bundled{at: Ref{URI: b.root}, node: b.document})
+
+	for len(b.queue) > 0 {
+		item := b.queue[0]
+		b.queue = b.queue[1:]
+
+		for _, site := range references(item.node, nil) {
+			source := Ref{URI: item.at.URI, Pointer: item.at.Pointer.Join(site.pointer)}
+
+			if err := b.rewrite(item.node, site, source); err != nil {
+				errs = append(errs, errAt(source, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// rewrite a reference of a bundled value as a local reference.
+func (b *bundler) rewrite(node any, site referenceSite, source Ref) error {
+	value, target, err := b.resolver.Resolve(source.URI, site.ref)
+	if err != nil {
+		return err
+	}
+
+	local := target.Pointer
+	if target.URI != b.root {
+		name, err := b.define(target, value)
+		if err != nil {
+			return err
+		}
+
+		local = jsonpointer.FromTokens(defsKey, name)
+	}
+
+	if _, err := site.pointer.Append(refKey).Set(node, Ref{Pointer: local}.String()); err != nil {
+		return errReplace(err)
+	}
+
+	return nil
+}
+
+// define returns the name of a value gathered under "$defs", copying the value the first time it
+// is referenced.
+func (b *bundler) define(target Ref, value any) (string, error) {
+	key := target.String()
+	if name, ok := b.names[key]; ok {
+		return name, nil
+	}
+
+	if b.defs == nil {
+		defs, err := b.defsObject()
+		if err != nil {
+			return "", err
+		}
+
+		b.defs = defs
+	}
+
+	name := b.uniqueName(target)
+	b.names[key] = name
+
+	node := copyJSON(value)
+	b.defs[name] = node
+	b.queue = append(b.queue, bundled{at: target, node: node})
+
+	return name, nil
+}
+
+// defsObject returns the "$defs" member of the bundled document, adding it if needed.
+func (b *bundler) defsObject() (map[string]any, error) {
+	object, ok := b.document.(map[string]any)
+	if !ok {
+		return nil, errNotObject(b.root)
+	}
+
+	existing, found := object[defsKey]
+	if !found {
+		defs := make(map[string]any)
+		object[defsKey] = defs
+
+		return defs, nil
+	}
+
+	defs, ok := existing.(map[string]any)
+	if !ok {
+		return nil, errNotObject(b.root + Ref{Pointer: jsonpointer.FromTokens(defsKey)}.String())
+	}
+
+	return defs, nil
+}
+
+// uniqueName derives the name of a gathered value from its location, e.g. "Pet" for
+// "models/pet.json#/Pet", or "pet" for "models/pet.json".
+//
+// Names already in use are suffixed with a number.
+func (b *bundler) uniqueName(target Ref) string {
+	base, ok := target.Pointer.Last()
+	if !ok || base == "" {
+		base = path.Base(target.URI)
+		base = strings.TrimSuffix(base, path.Ext(base))
+	}
+
+	name := base
+	for i := 2; ; i++ {
+		if _, taken := b.defs[name]; !taken {
+			return name
+		}
+
+		name = base + strconv.Itoa(i)
+	}
+}
+
+// copyJSON returns a copy of a decoded JSON value, that shares no object or array with the
+// original.
+func copyJSON(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		c := make(map[string]any, len(v))
+		for key, member := range v {
+			c[key] = copyJSON(member)
+		}
+
+		return c
+
+	case []any:
+		c := make([]any, len(v))
+		for i, element := range v {
+			c[i] = copyJSON(element)
+		}
+
+		return c
+
+	default:
+		return value
+	}
+}
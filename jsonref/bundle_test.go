@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonref
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestBundle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should gather referenced values", func(t *testing.T) {
+		t.Parallel()
+
+		loader := MemoryLoader{
+			"spec/root.json": []byte(`{
+				"definitions": {"Local": {"type": "integer"}},
+				"paths": {
+					"a": {"$ref": "models/pet.json#/Pet"},
+					"b": {"$ref": "models/pet.json#/Pet", "description": "kept"},
+					"c": {"$ref": "#/definitions/Local"},
+					"d": {"$ref": "models/tag.json"},
+					"e": {"$ref": "models/other/pet.json#/Pet"},
+					"f": {"$ref": "models/pet.json#/Alias"}
+				}
+			}`),
+			"spec/models/pet.json": []byte(`{
+				"Pet": {
+					"properties": {
+						"name": {"$ref": "#/Name"},
+						"owner": {"$ref": "../root.json#/definitions/Local"},
+						"parent": {"$ref": "#/Pet"}
+					}
+				},
+				"Name": {"type": "string"},
+				"Alias": {"$ref": "#/Pet"}
+			}`),
+			"spec/models/tag.json":       []byte(`{"type": "string"}`),
+			"spec/models/other/pet.json": []byte(`{"Pet": {"type": "null"}}`),
+		}
+
+		document, err := Bundle("spec/root.json", loader)
+		require.NoError(t, err)
+
+		assert.JSONEq(t, `{
+			"definitions": {"Local": {"type": "integer"}},
+			"paths": {
+				"a": {"$ref": "#/$defs/Pet"},
+				"b": {"$ref": "#/$defs/Pet", "description": "kept"},
+				"c": {"$ref": "#/definitions/Local"},
+				"d": {"$ref": "#/$defs/tag"},
+				"e": {"$ref": "#/$defs/Pet2"},
+				"f": {"$ref": "#/$defs/Pet"}
+			},
+			"$defs": {
+				"Pet": {
+					"properties": {
+						"name": {"$ref": "#/$defs/Name"},
+						"owner": {"$ref": "#/definitions/Local"},
+						"parent": {"$ref": "#/$defs/Pet"}
+					}
+				},
+				"Name": {"type": "string"},
+				"tag": {"type": "string"},
+				"Pet2": {"type": "null"}
+			}
+		}`, marshalJSON(t, document))
+
+		t.Run("should not modify documents", func(t *testing.T) {
+			again, err := Bundle("spec/root.json", loader)
+			require.NoError(t, err)
+			assert.JSONEq(t, marshalJSON(t, document), marshalJSON(t, again))
+		})
+	})
+
+	t.Run("should not override existing definitions", func(t *testing.T) {
+		t.Parallel()
+
+		document, err := Bundle("root.json", MemoryLoader{
+			"root.json": []byte(`{
+				"$defs": {"Pet": {"type": "object"}},
+				"a": {"$ref": "pet.json#/Pet"}
+			}`),
+			"pet.json": []byte(`{"Pet": {"type": "string"}}`),
+		})
+		require.NoError(t, err)
+
+		assert.JSONEq(t, `{
+			"$defs": {"Pet": {"type": "object"}, "Pet2": {"type": "string"}},
+			"a": {"$ref": "#/$defs/Pet2"}
+		}`, marshalJSON(t, document))
+	})
+
+	t.Run("should report unresolvable references", func(t *testing.T) {
+		t.Parallel()
+
+		document, err := Bundle("root.json", MemoryLoader{
+			"root.json": []byte(`{
+				"a": {"$ref": "missing.json#/x"},
+				"b": {"$ref": "other.json#/b"}
+			}`),
+			"other.json": []byte(`{"b": {"c": {"$ref": "#/missing"}}}`),
+		})
+		require.ErrorIs(t, err, ErrReference)
+		require.ErrorIs(t, err, fs.ErrNotExist)
+		require.ErrorContains(t, err, `reference at "root.json#/a"`)
+		require.ErrorContains(t, err, `reference at "other.json#/b/c"`)
+
+		assert.JSONEq(t, `{
+			"a": {"$ref": "missing.json#/x"},
+			"b": {"$ref": "#/$defs/b"},
+			"$defs": {"b": {"c": {"$ref": "#/missing"}}}
+		}`, marshalJSON(t, document))
+	})
+
+	t.Run("should not bundle into a non-object", func(t *testing.T) {
+		t.Parallel()
+
+		loader := MemoryLoader{
+			"array.json": []byte(`[{"$ref": "other.json"}]`),
+			"defs.json":  []byte(`{"$defs": [], "a": {"$ref": "other.json"}}`),
+			"other.json": []byte(`{}`),
+		}
+
+		for _, root := range []string{"array.json", "defs.json"} {
+			_, err := Bundle(root, loader)
+			require.ErrorIs(t, err, ErrReference)
+			require.ErrorContains(t, err, "not an object")
+		}
+	})
+
+	t.Run("with missing root document", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Bundle("root.json", MemoryLoader{})
+		require.ErrorIs(t, err, ErrReference)
+		require.ErrorIs(t, err, fs.ErrNotExist)
+	})
+}
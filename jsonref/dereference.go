@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonref
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/go-openapi/jsonpointer"
+	"github.com/go-openapi/jsonpointer/internal/identity"
+)
+
+// Dereference replaces every JSON reference object of a document by the value it references.
+//
+// Only local references, e.g. "#/definitions/Pet", are supported: use [Bundle] first to gather
+// the references to other documents into a single document. Chained references are followed like
+// by [Resolver.Resolve].
+//
+// References to the same value are replaced by this very value, so that shared values are
+// preserved: e.g. two references to the same go map are replaced by the same map. A reference that
+// would make a value contain itself, such as in a recursive schema, is left unchanged, so that the
+// dereferenced document remains a tree that may be marshaled to JSON.
+//
+// The document is modified in place, like with [jsonpointer.Pointer.Set], and the updated document
+// is returned. The options are used to resolve and to set values, e.g. to dereference go structs
+// with [jsonpointer.WithNameProvider].
+//
+// References that cannot be resolved are left unchanged, and reported by an error that joins one
+// error per reference, each mentioning the location of the reference in the document.
+func Dereference(document any, opts ...jsonpointer.Option) (any, error) {
+	r := NewResolver(nil, opts...)
+	r.AddDocument("", document)
+
+	var errs []error
+
+	for _, site := range references(document, opts) {
+		value, _, err := r.Resolve("", site.ref)
+		if err != nil {
+			errs = append(errs, errAt(Ref{Pointer: site.pointer}, err))
+
+			continue
+		}
+
+		cyclic, err := containsAncestor(document, site.pointer, value, opts)
+		if err != nil {
+			errs = append(errs, errAt(Ref{Pointer: site.pointer}, errReplace(err)))
+
+			continue
+		}
+
+		if cyclic {
+			continue
+		}
+
+		updated, err := site.pointer.Set(document, value, opts...)
+		if err != nil {
+			errs = append(errs, errAt(Ref{Pointer: site.pointer}, errReplace(err)))
+
+			continue
+		}
+
+		document = updated
+		r.AddDocument("", document) // e.g. an updated copy of a struct passed by value
+	}
+
+	return document, errors.Join(errs...)
+}
+
+// referenceSite is a JSON reference object found in a document.
+type referenceSite struct {
+	pointer jsonpointer.Pointer
+	ref     string
+}
+
+// references lists the JSON reference objects of a document, in a deterministic order.
+//
+// The members of a reference object are ignored, so references nested in a reference object are
+// not listed.
+//
+// Children are listed by a walk limited to the first level, so that options such as
+// [jsonpointer.WithLeavesOnly] or [jsonpointer.WithMaxDepth] do not hide any reference.
+func references(document any, opts []jsonpointer.Option) []referenceSite {
+	walkOpts := append(slices.Clip(opts), jsonpointer.WithSortedKeys(), jsonpointer.WithMaxDepth(1))
+
+	return collectReferences(document, jsonpointer.Pointer{}, nil, make(map[identity.Key]struct{}), opts, walkOpts)
+}
+
+func collectReferences(node any, at jsonpointer.Pointer, sites []referenceSite, visiting map[identity.Key]struct{}, opts, walkOpts []jsonpointer.Option) []referenceSite {
+	if ref, ok := refOf(node, opts); ok {
+		return append(sites, referenceSite{pointer: at, ref: ref})
+	}
+
+	if id, ok := identity.Of(node); ok {
+		if _, cyclic := visiting[id]; cyclic {
+			return sites
+		}
+
+		visiting[id] = struct{}{}
+		defer delete(visiting, id)
+	}
+
+	for pointer, child := range jsonpointer.Walk(node, walkOpts...) {
+		if pointer.IsEmpty() {
+			continue
+		}
+
+		sites = collectReferences(child, at.Join(pointer), sites, visiting, opts, walkOpts)
+	}
+
+	return sites
+}
+
+// containsAncestor tells if a value contains one of the ancestors of a location of the document,
+// in which case setting the value at this location would make the document cyclic.
+func containsAncestor(document any, pointer jsonpointer.Pointer, value any, opts []jsonpointer.Option) (bool, error) {
+	ancestors := make(map[identity.Key]struct{})
+	ancestor := pointer
+
+	for !ancestor.IsEmpty() {
+		ancestor = ancestor.Parent()
+
+		node, _, err := ancestor.Get(document, opts...)
+		if err != nil {
+			return false, err
+		}
+
+		if id, ok := identity.Of(node); ok {
+			ancestors[id] = struct{}{}
+		}
+	}
+
+	return reaches(value, ancestors, make(map[identity.Key]struct{}), opts), nil
+}
+
+// reaches tells if one of the targets may be reached from a node.
+//
+// Nodes that are already known not to reach any target are skipped, so that shared values are
+// traversed only once.
+func reaches(node any, targets, seen map[identity.Key]struct{}, opts []jsonpointer.Option) bool {
+	if id, ok := identity.Of(node); ok {
+		if _, found := targets[id]; found {
+			return true
+		}
+
+		if _, done := seen[id]; done {
+			return false
+		}
+
+		seen[id] = struct{}{}
+	}
+
+	for pointer, child := range jsonpointer.Walk(node, append(slices.Clip(opts), jsonpointer.WithMaxDepth(1))...) {
+		if pointer.IsEmpty() {
+			continue
+		}
+
+		if reaches(child, targets, seen, opts) {
+			return true
+		}
+	}
+
+	return false
+}
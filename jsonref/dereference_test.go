@@ -0,0 +1,192 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonref
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/jsonpointer"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func decodeJSON(t *testing.T, data string) any {
+	t.Helper()
+
+	var document any
+	require.NoError(t, json.Unmarshal([]byte(data), &document))
+
+	return document
+}
+
+func marshalJSON(t *testing.T, document any) string {
+	t.Helper()
+
+	data, err := json.Marshal(document)
+	require.NoError(t, err)
+
+	return string(data)
+}
+
+func valueAt(t *testing.T, document any, pointer string) any {
+	t.Helper()
+
+	p, err := jsonpointer.New(pointer)
+	require.NoError(t, err)
+
+	value, _, err := p.Get(document)
+	require.NoError(t, err)
+
+	return value
+}
+
+func sameValue(a, b any) bool {
+	return reflect.ValueOf(a).Pointer() == reflect.ValueOf(b).Pointer()
+}
+
+func TestDereference(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should inline references", func(t *testing.T) {
+		t.Parallel()
+
+		document, err := Dereference(decodeJSON(t, `{
+			"definitions": {
+				"Pet": {"type": "object", "properties": {"name": {"$ref": "#/definitions/Name"}}},
+				"Name": {"type": "string"},
+				"Alias": {"$ref": "#/definitions/Pet"}
+			},
+			"paths": {
+				"/pets": {"items": {"$ref": "#/definitions/Pet"}},
+				"/pets/{id}": {"$ref": "#/definitions/Alias", "description": "ignored"},
+				"/names": [{"$ref": "#/definitions/Pet/properties/name"}]
+			}
+		}`))
+		require.NoError(t, err)
+
+		pet := `{"type": "object", "properties": {"name": {"type": "string"}}}`
+		assert.JSONEq(t, `{
+			"definitions": {
+				"Pet": `+pet+`,
+				"Name": {"type": "string"},
+				"Alias": `+pet+`
+			},
+			"paths": {
+				"/pets": {"items": `+pet+`},
+				"/pets/{id}": `+pet+`,
+				"/names": [{"type": "string"}]
+			}
+		}`, marshalJSON(t, document))
+
+		t.Run("should preserve shared values", func(t *testing.T) {
+			pet := valueAt(t, document, "/definitions/Pet")
+			assert.TrueT(t, sameValue(pet, valueAt(t, document, "/definitions/Alias")))
+			assert.TrueT(t, sameValue(pet, valueAt(t, document, "/paths/~1pets/items")))
+			assert.TrueT(t, sameValue(pet, valueAt(t, document, "/paths/~1pets~1{id}")))
+
+			name := valueAt(t, document, "/definitions/Name")
+			assert.TrueT(t, sameValue(name, valueAt(t, document, "/definitions/Pet/properties/name")))
+			assert.TrueT(t, sameValue(name, valueAt(t, document, "/paths/~1names/0")))
+		})
+	})
+
+	t.Run("should leave cycles as references", func(t *testing.T) {
+		t.Parallel()
+
+		document, err := Dereference(decodeJSON(t, `{
+			"definitions": {
+				"Node": {"properties": {"next": {"$ref": "#/definitions/Node"}}},
+				"A": {"properties": {"b": {"$ref": "#/definitions/B"}}},
+				"B": {"properties": {"a": {"$ref": "#/definitions/A"}}}
+			},
+			"node": {"$ref": "#/definitions/Node"},
+			"a": {"$ref": "#/definitions/A"}
+		}`))
+		require.NoError(t, err)
+
+		a := `{"properties": {"b": {"properties": {"a": {"$ref": "#/definitions/A"}}}}}`
+		node := `{"properties": {"next": {"$ref": "#/definitions/Node"}}}`
+		assert.JSONEq(t, `{
+			"definitions": {
+				"Node": `+node+`,
+				"A": `+a+`,
+				"B": {"properties": {"a": {"$ref": "#/definitions/A"}}}
+			},
+			"node": `+node+`,
+			"a": `+a+`
+		}`, marshalJSON(t, document))
+	})
+
+	t.Run("should ignore walk options", func(t *testing.T) {
+		t.Parallel()
+
+		for _, opt := range []jsonpointer.Option{jsonpointer.WithLeavesOnly(), jsonpointer.WithMaxDepth(1)} {
+			document, err := Dereference(decodeJSON(t, `{
+				"definitions": {"Name": {"type": "string"}},
+				"a": {"b": {"$ref": "#/definitions/Name"}}
+			}`), opt)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, `{
+				"definitions": {"Name": {"type": "string"}},
+				"a": {"b": {"type": "string"}}
+			}`, marshalJSON(t, document))
+		}
+	})
+
+	t.Run("should report unresolvable references", func(t *testing.T) {
+		t.Parallel()
+
+		document, err := Dereference(decodeJSON(t, `{
+			"a": {"$ref": "#/missing"},
+			"b": {"$ref": "other.json#/b"},
+			"c": {"$ref": "#/d"},
+			"d": 1,
+			"e": {"$ref": "#/e"}
+		}`))
+		require.ErrorIs(t, err, ErrReference)
+		require.ErrorIs(t, err, ErrCyclicReference)
+		require.ErrorIs(t, err, jsonpointer.ErrPointer)
+		require.ErrorContains(t, err, `reference at "#/a"`)
+		require.ErrorContains(t, err, `reference at "#/b"`)
+		require.ErrorContains(t, err, `reference at "#/e"`)
+		assert.NotContains(t, err.Error(), `reference at "#/c"`)
+
+		assert.JSONEq(t, `{
+			"a": {"$ref": "#/missing"},
+			"b": {"$ref": "other.json#/b"},
+			"c": 1,
+			"d": 1,
+			"e": {"$ref": "#/e"}
+		}`, marshalJSON(t, document))
+	})
+
+	t.Run("with go structs", func(t *testing.T) {
+		t.Parallel()
+
+		type Schema struct {
+			Ref        string             `json:"$ref,omitempty"`
+			Type       string             `json:"type,omitempty"`
+			Properties map[string]*Schema `json:"properties,omitempty"`
+		}
+
+		name := &Schema{Type: "string"}
+		document := &Schema{
+			Properties: map[string]*Schema{
+				"name":  name,
+				"alias": {Ref: "#/properties/name"},
+				"self":  {Ref: "#"},
+			},
+		}
+
+		updated, err := Dereference(document)
+		require.NoError(t, err)
+
+		assert.Same(t, document, updated)
+		assert.Same(t, name, document.Properties["alias"])
+		assert.EqualT(t, "#", document.Properties["self"].Ref)
+	})
+}
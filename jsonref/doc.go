@@ -0,0 +1,14 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+// Package jsonref resolves JSON references, i.e. {"$ref": "other.json#/definitions/Pet"} objects,
+// across documents.
+//
+// A reference is made of the URI of a document, and of a json pointer in its fragment. Referenced
+// documents are loaded by a [Loader], then decoded and cached by a [Resolver], which resolves the
+// pointer with [jsonpointer.Pointer.Get].
+//
+// A multi-document specification may be gathered into a single document with local references by
+// [Bundle], then all local references may be replaced by the values they reference with
+// [Dereference].
+package jsonref
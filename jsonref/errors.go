@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonref
+
+import "fmt"
+
+type refError string
+
+func (e refError) Error() string {
+	return string(e)
+}
+
+const (
+	// ErrReference is a sentinel error raised by all errors from this package.
+	ErrReference refError = "JSON reference error"
+
+	// ErrCyclicReference indicates that a chain of references loops back to a reference already
+	// being resolved.
+	ErrCyclicReference refError = "cyclic JSON reference"
+)
+
+func errInvalidRef(ref string, err error) error {
+	return fmt.Errorf("invalid reference %q: %w: %w", ref, err, ErrReference)
+}
+
+func errCyclicRef(ref Ref) error {
+	return fmt.Errorf("reference %q loops back to itself: %w: %w", ref, ErrCyclicReference, ErrReference)
+}
+
+func errUnresolvable(ref Ref, err error) error {
+	return fmt.Errorf("cannot resolve reference %q: %w: %w", ref, err, ErrReference)
+}
+
+func errLoad(uri string, err error) error {
+	return fmt.Errorf("cannot load document %q: %w: %w", uri, err, ErrReference)
+}
+
+func errUnsupportedScheme(uri string) error {
+	return fmt.Errorf("cannot load document %q: unsupported URI scheme: %w", uri, ErrReference)
+}
+
+func errNoLoader(uri string) error {
+	return fmt.Errorf("cannot load document %q: no loader: %w", uri, ErrReference)
+}
+
+func errReplace(err error) error {
+	return fmt.Errorf("cannot replace reference: %w: %w", err, ErrReference)
+}
+
+func errAt(source Ref, err error) error {
+	return fmt.Errorf("reference at %q: %w", source, err)
+}
+
+func errNotObject(location string) error {
+	return fmt.Errorf("cannot bundle values into %q: not an object: %w", location, ErrReference)
+}
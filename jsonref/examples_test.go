@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonref_test
+
+import (
+	"fmt"
+
+	"github.com/go-openapi/jsonpointer/jsonref"
+)
+
+func ExampleResolver_Resolve() {
+	resolver := jsonref.NewResolver(jsonref.MemoryLoader{
+		"spec/root.json": []byte(`{
+			"definitions": {
+				"Pet": {"$ref": "models/pet.json#/Pet"}
+			}
+		}`),
+		"spec/models/pet.json": []byte(`{
+			"Pet": {
+				"properties": {
+					"name": {"type": "string"}
+				}
+			}
+		}`),
+	})
+
+	value, at, err := resolver.Resolve("spec/root.json", "#/definitions/Pet/properties/name")
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	fmt.Printf("%s: %v\n", at, value)
+
+	// Output:
+	// spec/models/pet.json#/Pet/properties/name: map[type:string]
+}
+
+func ExampleBundle() {
+	loader := jsonref.MemoryLoader{
+		"spec/root.json": []byte(`{
+			"pet": {"$ref": "models/pet.json#/Pet"},
+			"pets": {"items": {"$ref": "models/pet.json#/Pet"}}
+		}`),
+		"spec/models/pet.json": []byte(`{
+			"Pet": {"type": "object"}
+		}`),
+	}
+
+	bundled, err := jsonref.Bundle("spec/root.json", loader)
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	fmt.Println(bundled)
+
+	dereferenced, err := jsonref.Dereference(bundled)
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
+	fmt.Println(dereferenced)
+
+	// Output:
+	// map[$defs:map[Pet:map[type:object]] pet:map[$ref:#/$defs/Pet] pets:map[items:map[$ref:#/$defs/Pet]]]
+	// map[$defs:map[Pet:map[type:object]] pet:map[type:object] pets:map[items:map[type:object]]]
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonref
+
+import (
+	"fmt"
+	"io/fs"
+	"net/url"
+	"strings"
+)
+
+// Loader loads the raw JSON content of a document, given its URI.
+//
+// The URI is resolved against the URI of the referring document: it has no fragment.
+type Loader interface {
+	Load(uri string) ([]byte, error)
+}
+
+var (
+	_ Loader = (*FSLoader)(nil)
+	_ Loader = MemoryLoader(nil)
+)
+
+// FSLoader loads documents from a file system.
+//
+// URIs are paths relative to the root of the file system, e.g. "spec/other.json". URIs with the
+// "file" scheme are supported, and a leading "/" is removed from their path. Other schemes are not
+// supported.
+type FSLoader struct {
+	fsys fs.FS
+}
+
+// NewFSLoader creates a [Loader] for the documents of a file system, e.g. [os.DirFS].
+func NewFSLoader(fsys fs.FS) *FSLoader {
+	return &FSLoader{fsys: fsys}
+}
+
+// Load a document from the file system.
+func (l *FSLoader) Load(uri string) ([]byte, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, errLoad(uri, err)
+	}
+
+	if u.Scheme != "" && u.Scheme != "file" {
+		return nil, errUnsupportedScheme(uri)
+	}
+
+	content, err := fs.ReadFile(l.fsys, strings.TrimPrefix(u.Path, "/"))
+	if err != nil {
+		return nil, errLoad(uri, err)
+	}
+
+	return content, nil
+}
+
+// MemoryLoader loads documents from memory, indexed by their URI.
+//
+// This is mostly useful for testing.
+type MemoryLoader map[string][]byte
+
+// Load a document from memory.
+func (l MemoryLoader) Load(uri string) ([]byte, error) {
+	content, ok := l[uri]
+	if !ok {
+		return nil, errLoad(uri, fmt.Errorf("no such document: %w", fs.ErrNotExist))
+	}
+
+	return content, nil
+}
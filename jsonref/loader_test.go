@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonref
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestFSLoader(t *testing.T) {
+	t.Parallel()
+
+	loader := NewFSLoader(fstest.MapFS{
+		"spec/root.json": &fstest.MapFile{Data: []byte(`{"a": 1}`)},
+	})
+
+	for _, uri := range []string{"spec/root.json", "/spec/root.json", "file:///spec/root.json"} {
+		t.Run(uri, func(t *testing.T) {
+			t.Parallel()
+
+			content, err := loader.Load(uri)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"a": 1}`, string(content))
+		})
+	}
+
+	t.Run("with missing document", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := loader.Load("spec/missing.json")
+		require.ErrorIs(t, err, ErrReference)
+		require.ErrorIs(t, err, fs.ErrNotExist)
+	})
+
+	t.Run("with unsupported scheme", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := loader.Load("https://example.com/spec/root.json")
+		require.ErrorIs(t, err, ErrReference)
+	})
+}
+
+func TestMemoryLoader(t *testing.T) {
+	t.Parallel()
+
+	loader := MemoryLoader{"root.json": []byte(`{"a": 1}`)}
+
+	content, err := loader.Load("root.json")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"a": 1}`, string(content))
+
+	_, err = loader.Load("missing.json")
+	require.ErrorIs(t, err, ErrReference)
+	require.ErrorIs(t, err, fs.ErrNotExist)
+}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonref
+
+import (
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/go-openapi/jsonpointer"
+)
+
+// refKey is the key of a JSON reference object.
+const refKey = "$ref"
+
+// Ref is a JSON reference, split into a document URI and a json pointer.
+type Ref struct {
+	// URI of the referenced document, without fragment. It is empty for a reference to the current
+	// document, e.g. "#/definitions/Pet".
+	URI string
+
+	// Pointer to the referenced value in the document.
+	Pointer jsonpointer.Pointer
+}
+
+// ParseRef parses a JSON reference, e.g. "other.json#/definitions/Pet".
+//
+// The fragment is parsed like by [jsonpointer.NewFromFragment]: fragments that are not json
+// pointers, such as JSON schema anchors, are not supported.
+func ParseRef(ref string) (Ref, error) {
+	uri, fragment, _ := strings.Cut(ref, "#")
+
+	pointer, err := jsonpointer.NewFromFragment("#" + fragment)
+	if err != nil {
+		return Ref{}, errInvalidRef(ref, err)
+	}
+
+	return Ref{URI: uri, Pointer: pointer}, nil
+}
+
+// String representation of the reference, with the pointer as a URI fragment.
+func (r Ref) String() string {
+	if r.Pointer.IsEmpty() && r.URI != "" {
+		return r.URI
+	}
+
+	return r.URI + r.Pointer.Fragment()
+}
+
+// IsLocal tells if the reference points to the current document.
+func (r Ref) IsLocal() bool {
+	return r.URI == ""
+}
+
+// resolveURI resolves the URI of a referenced document against the URI of the referring document.
+//
+// Unlike [url.URL.ResolveReference], relative base URIs are supported, e.g. "spec/root.json" and
+// "other.json" resolve to "spec/other.json".
+func resolveURI(base, uri string) (string, error) {
+	if uri == "" {
+		return base, nil
+	}
+
+	ref, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+
+	if ref.IsAbs() || ref.Host != "" {
+		return ref.String(), nil
+	}
+
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+
+	if baseURL.IsAbs() || baseURL.Host != "" || strings.HasPrefix(baseURL.Path, "/") {
+		return baseURL.ResolveReference(ref).String(), nil
+	}
+
+	if strings.HasPrefix(ref.Path, "/") {
+		return ref.String(), nil
+	}
+
+	ref.Path = path.Join(path.Dir(baseURL.Path), ref.Path)
+
+	return ref.String(), nil
+}
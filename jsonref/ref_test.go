@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonref
+
+import (
+	"testing"
+
+	"github.com/go-openapi/jsonpointer"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestParseRef(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		ref     string
+		uri     string
+		pointer string
+		str     string
+		local   bool
+	}{
+		{ref: "#/definitions/Pet", pointer: "/definitions/Pet", str: "#/definitions/Pet", local: true},
+		{ref: "#", str: "#", local: true},
+		{ref: "", str: "#", local: true},
+		{ref: "other.json", uri: "other.json", str: "other.json"},
+		{ref: "other.json#", uri: "other.json", str: "other.json"},
+		{ref: "other.json#/definitions/Pet", uri: "other.json", pointer: "/definitions/Pet", str: "other.json#/definitions/Pet"},
+		{ref: "https://example.com/a.json#/a~1b/%7Bid%7D", uri: "https://example.com/a.json", pointer: "/a~1b/{id}", str: "https://example.com/a.json#/a~1b/%7Bid%7D"},
+	} {
+		t.Run(tc.ref, func(t *testing.T) {
+			t.Parallel()
+
+			ref, err := ParseRef(tc.ref)
+			require.NoError(t, err)
+
+			assert.EqualT(t, tc.uri, ref.URI)
+			assert.EqualT(t, tc.pointer, ref.Pointer.String())
+			assert.EqualT(t, tc.str, ref.String())
+			assert.EqualT(t, tc.local, ref.IsLocal())
+		})
+	}
+
+	t.Run("with a fragment that is not a pointer", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseRef("other.json#anchor")
+		require.ErrorIs(t, err, ErrReference)
+		require.ErrorIs(t, err, jsonpointer.ErrPointer)
+	})
+}
+
+func TestResolveURI(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		base     string
+		uri      string
+		expected string
+	}{
+		{base: "spec/root.json", uri: "", expected: "spec/root.json"},
+		{base: "spec/root.json", uri: "other.json", expected: "spec/other.json"},
+		{base: "spec/root.json", uri: "./models/pet.json", expected: "spec/models/pet.json"},
+		{base: "spec/models/pet.json", uri: "../root.json", expected: "spec/root.json"},
+		{base: "root.json", uri: "other.json", expected: "other.json"},
+		{base: "", uri: "other.json", expected: "other.json"},
+		{base: "spec/root.json", uri: "/abs.json", expected: "/abs.json"},
+		{base: "/spec/root.json", uri: "other.json", expected: "/spec/other.json"},
+		{base: "https://example.com/spec/root.json", uri: "other.json", expected: "https://example.com/spec/other.json"},
+		{base: "spec/root.json", uri: "https://example.com/a.json", expected: "https://example.com/a.json"},
+	} {
+		t.Run(tc.base+" + "+tc.uri, func(t *testing.T) {
+			t.Parallel()
+
+			uri, err := resolveURI(tc.base, tc.uri)
+			require.NoError(t, err)
+			assert.EqualT(t, tc.expected, uri)
+		})
+	}
+}
@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonref
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+
+	"github.com/go-openapi/jsonpointer"
+)
+
+// Resolver resolves JSON references across documents.
+//
+// Documents are loaded by a [Loader] the first time they are referenced, then decoded and cached.
+// Documents may also be registered with [Resolver.AddDocument], e.g. a root document that has
+// already been decoded.
+//
+// A Resolver may be safely used by several goroutines.
+type Resolver struct {
+	loader Loader
+	opts   []jsonpointer.Option
+
+	mu        sync.Mutex
+	documents map[string]any
+}
+
+// NewResolver creates a [Resolver] that loads documents with a [Loader].
+//
+// The options are used to resolve pointers, like with [jsonpointer.Pointer.Get], e.g. to resolve
+// references in go structs with [jsonpointer.WithNameProvider].
+func NewResolver(loader Loader, opts ...jsonpointer.Option) *Resolver {
+	return &Resolver{
+		loader:    loader,
+		opts:      opts,
+		documents: make(map[string]any),
+	}
+}
+
+// AddDocument registers a document under a URI, so that it is not loaded.
+//
+// The empty URI is allowed, e.g. for a root document that is not stored anywhere: references
+// relative to the empty URI are resolved like relative paths.
+func (r *Resolver) AddDocument(uri string, document any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.documents[uri] = document
+}
+
+// Document returns the document at a URI, loading and decoding it if it is not cached yet.
+//
+// Documents are loaded without holding a lock, so that slow loaders do not block other lookups.
+// When several goroutines load the same document concurrently, the first decoded document is
+// cached and returned to all of them.
+func (r *Resolver) Document(uri string) (any, error) {
+	r.mu.Lock()
+	document, ok := r.documents[uri]
+	r.mu.Unlock()
+
+	if ok {
+		return document, nil
+	}
+
+	if r.loader == nil {
+		return nil, errNoLoader(uri)
+	}
+
+	content, err := r.loader.Load(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(content, &document); err != nil {
+		return nil, errLoad(uri, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if cached, ok := r.documents[uri]; ok {
+		return cached, nil
+	}
+
+	r.documents[uri] = document
+
+	return document, nil
+}
+
+// Resolve a reference found in the document at the base URI, and returns the referenced value along
+// with its location.
+//
+// Chained references are followed: when the referenced value, or any value traversed by the
+// pointer, is itself a JSON reference object, it is resolved in turn, relative to the document that
+// holds it. The returned location is the one of the final value.
+//
+// A JSON reference object is an object with a "$ref" member of type string. Its other members are
+// ignored.
+//
+// A chain of references that loops back to itself is reported by an error wrapping
+// [ErrCyclicReference]. Other errors, e.g. when a document cannot be loaded or a pointer cannot be
+// resolved, wrap [ErrReference].
+func (r *Resolver) Resolve(base, ref string) (any, Ref, error) {
+	target, err := ParseRef(ref)
+	if err != nil {
+		return nil, Ref{}, err
+	}
+
+	if target.URI, err = resolveURI(base, target.URI); err != nil {
+		return nil, Ref{}, errInvalidRef(ref, err)
+	}
+
+	return r.resolve(target, make(map[string]struct{}))
+}
+
+// resolve a reference, with a document URI already resolved.
+//
+// The references being resolved are tracked to detect cycles. A reference is only visited while it is
+// being resolved, so that a recursive value (e.g. a schema with a property referring to itself) may be
+// traversed once its reference has been followed.
+func (r *Resolver) resolve(target Ref, visited map[string]struct{}) (any, Ref, error) {
+	key := target.String()
+	if _, cyclic := visited[key]; cyclic {
+		return nil, target, errCyclicRef(target)
+	}
+	visited[key] = struct{}{}
+	defer delete(visited, key)
+
+	node, err := r.Document(target.URI)
+	if err != nil {
+		return nil, target, err
+	}
+
+	at := Ref{URI: target.URI}
+
+	for token := range target.Pointer.Tokens() {
+		if node, at, err = r.follow(node, at, visited); err != nil {
+			return nil, at, err
+		}
+
+		node, _, err = jsonpointer.GetForToken(node, token, r.opts...)
+		if err != nil {
+			return nil, target, errUnresolvable(target, err)
+		}
+
+		at.Pointer = at.Pointer.Append(token)
+	}
+
+	return r.follow(node, at, visited)
+}
+
+// follow the reference held by a node, if any.
+func (r *Resolver) follow(node any, at Ref, visited map[string]struct{}) (any, Ref, error) {
+	ref, ok := refOf(node, r.opts)
+	if !ok {
+		return node, at, nil
+	}
+
+	next, err := ParseRef(ref)
+	if err != nil {
+		return nil, at, err
+	}
+
+	if next.URI, err = resolveURI(at.URI, next.URI); err != nil {
+		return nil, at, errInvalidRef(ref, err)
+	}
+
+	return r.resolve(next, visited)
+}
+
+// refOf returns the reference held by a JSON reference object, i.e. the value of its "$ref" member.
+func refOf(node any, opts []jsonpointer.Option) (string, bool) {
+	if object, ok := node.(map[string]any); ok {
+		ref, ok := object[refKey].(string)
+
+		return ref, ok && ref != ""
+	}
+
+	switch reflect.Indirect(reflect.ValueOf(node)).Kind() {
+	case reflect.Map, reflect.Struct:
+	default:
+		return "", false
+	}
+
+	value, _, err := jsonpointer.GetForToken(node, refKey, opts...)
+	if err != nil {
+		return "", false
+	}
+
+	ref, ok := value.(string)
+
+	return ref, ok && ref != ""
+}
@@ -0,0 +1,275 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonref
+
+import (
+	"encoding/json"
+	"io/fs"
+	"sync"
+	"testing"
+
+	"github.com/go-openapi/jsonpointer"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+// countingLoader counts the documents loaded by a loader.
+type countingLoader struct {
+	Loader
+
+	mu    sync.Mutex
+	loads map[string]int
+}
+
+func (l *countingLoader) Load(uri string) ([]byte, error) {
+	l.mu.Lock()
+	l.loads[uri]++
+	l.mu.Unlock()
+
+	return l.Loader.Load(uri)
+}
+
+// loaderFunc adapts a function to a [Loader].
+type loaderFunc func(uri string) ([]byte, error)
+
+func (f loaderFunc) Load(uri string) ([]byte, error) {
+	return f(uri)
+}
+
+func testLoader() *countingLoader {
+	return &countingLoader{
+		Loader: MemoryLoader{
+			"spec/root.json": []byte(`{
+				"definitions": {
+					"Pet": {"$ref": "models/pet.json#/Pet"},
+					"Alias": {"$ref": "#/definitions/Pet"},
+					"Name": {"$ref": "#/definitions/Pet/properties/name"},
+					"Self": {"$ref": "#/definitions/Self"},
+					"Ping": {"$ref": "#/definitions/Pong"},
+					"Pong": {"$ref": "models/pet.json#/Ping"},
+					"Broken": {"$ref": "models/pet.json#/Missing"}
+				}
+			}`),
+			"spec/models/pet.json": []byte(`{
+				"Pet": {
+					"type": "object",
+					"properties": {
+						"name": {"$ref": "#/Name"}
+					}
+				},
+				"Name": {"type": "string"},
+				"Ping": {"$ref": "../root.json#/definitions/Ping"}
+			}`),
+			"spec/invalid.json": []byte(`{`),
+		},
+		loads: make(map[string]int),
+	}
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		ref      string
+		expected string
+		at       string
+	}{
+		{ref: "#/definitions", expected: "", at: "spec/root.json#/definitions"},
+		{ref: "models/pet.json#/Name", expected: `{"type": "string"}`, at: "spec/models/pet.json#/Name"},
+		{ref: "#/definitions/Pet/type", expected: `"object"`, at: "spec/models/pet.json#/Pet/type"},
+		{ref: "#/definitions/Alias/properties/name", expected: `{"type": "string"}`, at: "spec/models/pet.json#/Name"},
+		{ref: "#/definitions/Name", expected: `{"type": "string"}`, at: "spec/models/pet.json#/Name"},
+		{ref: "models/pet.json", expected: "", at: "spec/models/pet.json"},
+	} {
+		t.Run(tc.ref, func(t *testing.T) {
+			t.Parallel()
+
+			r := NewResolver(testLoader())
+
+			value, at, err := r.Resolve("spec/root.json", tc.ref)
+			require.NoError(t, err)
+			assert.EqualT(t, tc.at, at.String())
+
+			if tc.expected != "" {
+				actual, err := json.Marshal(value)
+				require.NoError(t, err)
+				assert.JSONEq(t, tc.expected, string(actual))
+			}
+		})
+	}
+
+	t.Run("with cyclic references", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewResolver(testLoader())
+
+		for _, ref := range []string{"#/definitions/Self", "#/definitions/Ping", "#/definitions/Self/properties"} {
+			_, _, err := r.Resolve("spec/root.json", ref)
+			require.ErrorIs(t, err, ErrCyclicReference)
+			require.ErrorIs(t, err, ErrReference)
+		}
+	})
+
+	t.Run("with recursive references", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewResolver(nil)
+		r.AddDocument("", decodeJSON(t, `{
+			"definitions": {
+				"Node": {"properties": {"next": {"$ref": "#/definitions/Node"}}}
+			},
+			"root": {"$ref": "#/definitions/Node"}
+		}`))
+
+		value, at, err := r.Resolve("", "#/root/properties/next")
+		require.NoError(t, err)
+		assert.EqualT(t, "#/definitions/Node", at.String())
+		assert.JSONEq(t, `{"properties": {"next": {"$ref": "#/definitions/Node"}}}`, marshalJSON(t, value))
+	})
+
+	t.Run("with unresolvable pointer", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewResolver(testLoader())
+
+		_, _, err := r.Resolve("spec/root.json", "#/definitions/Broken")
+		require.ErrorIs(t, err, ErrReference)
+		require.ErrorIs(t, err, jsonpointer.ErrPointer)
+		require.ErrorContains(t, err, "spec/models/pet.json#/Missing")
+	})
+
+	t.Run("with missing document", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewResolver(testLoader())
+
+		_, _, err := r.Resolve("spec/root.json", "missing.json#/a")
+		require.ErrorIs(t, err, ErrReference)
+		require.ErrorIs(t, err, fs.ErrNotExist)
+	})
+
+	t.Run("with invalid document", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewResolver(testLoader())
+
+		_, _, err := r.Resolve("spec/root.json", "invalid.json")
+		require.ErrorIs(t, err, ErrReference)
+	})
+
+	t.Run("with invalid reference", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewResolver(testLoader())
+
+		_, _, err := r.Resolve("spec/root.json", "#anchor")
+		require.ErrorIs(t, err, ErrReference)
+	})
+
+	t.Run("should cache documents", func(t *testing.T) {
+		t.Parallel()
+
+		loader := testLoader()
+		r := NewResolver(loader)
+
+		for range 3 {
+			_, _, err := r.Resolve("spec/root.json", "#/definitions/Alias")
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, map[string]int{"spec/root.json": 1, "spec/models/pet.json": 1}, loader.loads)
+	})
+
+	t.Run("should not lock documents while loading", func(t *testing.T) {
+		t.Parallel()
+
+		var r *Resolver
+		r = NewResolver(loaderFunc(func(uri string) ([]byte, error) {
+			if uri == "spec/root.json" {
+				// a loader may look up other documents, e.g. to compose its content
+				if _, err := r.Document("spec/models/pet.json"); err != nil {
+					return nil, err
+				}
+			}
+
+			return testLoader().Load(uri)
+		}))
+
+		_, _, err := r.Resolve("spec/root.json", "#/definitions/Pet")
+		require.NoError(t, err)
+	})
+
+	t.Run("should share documents loaded concurrently", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewResolver(testLoader())
+		documents := make([]any, 8)
+
+		var wg sync.WaitGroup
+		for i := range documents {
+			wg.Go(func() {
+				document, err := r.Document("spec/root.json")
+				assert.NoError(t, err)
+				documents[i] = document
+			})
+		}
+		wg.Wait()
+
+		for _, document := range documents[1:] {
+			assert.TrueT(t, sameValue(documents[0], document))
+		}
+	})
+}
+
+func TestResolver_AddDocument(t *testing.T) {
+	t.Parallel()
+
+	t.Run("without loader", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewResolver(nil)
+		r.AddDocument("", map[string]any{
+			"a": map[string]any{"$ref": "#/b"},
+			"b": 1,
+		})
+
+		value, at, err := r.Resolve("", "#/a")
+		require.NoError(t, err)
+		assert.Equal(t, 1, value)
+		assert.EqualT(t, "#/b", at.String())
+
+		_, _, err = r.Resolve("", "other.json")
+		require.ErrorIs(t, err, ErrReference)
+	})
+
+	t.Run("with go structs", func(t *testing.T) {
+		t.Parallel()
+
+		type Schema struct {
+			Ref        string             `json:"$ref,omitempty"`
+			Type       string             `json:"type,omitempty"`
+			Properties map[string]*Schema `json:"properties,omitempty"`
+		}
+
+		r := NewResolver(testLoader())
+		r.AddDocument("spec/typed.json", &Schema{
+			Properties: map[string]*Schema{
+				"pet":  {Ref: "models/pet.json#/Pet"},
+				"name": {Ref: "#/properties/alias"},
+				// an empty $ref field is not a reference
+				"alias": {Type: "string"},
+			},
+		})
+
+		value, at, err := r.Resolve("spec/typed.json", "#/properties/name")
+		require.NoError(t, err)
+		assert.EqualT(t, "spec/typed.json#/properties/alias", at.String())
+		assert.Equal(t, &Schema{Type: "string"}, value)
+
+		value, at, err = r.Resolve("spec/typed.json", "#/properties/pet/type")
+		require.NoError(t, err)
+		assert.EqualT(t, "spec/models/pet.json#/Pet/type", at.String())
+		assert.Equal(t, "object", value)
+	})
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpointer
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestMarshalText(t *testing.T) {
+	t.Parallel()
+
+	type config struct {
+		Target   Pointer   `json:"target"`
+		Optional *Pointer  `json:"optional,omitempty"`
+		List     []Pointer `json:"list"`
+	}
+
+	t.Run("should marshal pointers as JSON strings", func(t *testing.T) {
+		cfg := config{
+			Target: FromTokens("paths", "/pets/{id}", "get"),
+			List:   []Pointer{FromTokens(), FromTokens("a")},
+		}
+
+		out, err := json.Marshal(cfg)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"target": "/paths/~1pets~1{id}/get", "list": ["", "/a"]}`, string(out))
+	})
+
+	t.Run("should unmarshal pointers from JSON strings", func(t *testing.T) {
+		var cfg config
+		require.NoError(t, json.Unmarshal([]byte(`{"target": "/foo/1", "optional": "/a~1b", "list": ["", "/obj/a"]}`), &cfg))
+
+		assert.EqualT(t, "/foo/1", cfg.Target.String())
+		require.NotNil(t, cfg.Optional)
+		assert.Equal(t, []string{"a/b"}, cfg.Optional.DecodedTokens())
+		require.Len(t, cfg.List, 2)
+		assert.TrueT(t, cfg.List[0].IsEmpty())
+
+		v, _, err := cfg.Target.Get(testDocumentJSON(t))
+		require.NoError(t, err)
+		assert.Equal(t, "baz", v)
+	})
+
+	t.Run("should replace previous tokens", func(t *testing.T) {
+		p := FromTokens("a", "b")
+		require.NoError(t, p.UnmarshalText([]byte("/c")))
+		assert.EqualT(t, "/c", p.String())
+	})
+
+	t.Run("should reject invalid pointers", func(t *testing.T) {
+		var cfg config
+		err := json.Unmarshal([]byte(`{"target": "foo"}`), &cfg)
+		require.Error(t, err)
+		require.ErrorIs(t, err, ErrInvalidStart)
+		require.ErrorIs(t, err, ErrPointer)
+
+		p := FromTokens("a")
+		require.ErrorIs(t, p.UnmarshalText([]byte("b")), ErrInvalidStart)
+		assert.EqualT(t, "/a", p.String(), "pointer should be left unchanged on error")
+	})
+
+	t.Run("should preserve strict mode", func(t *testing.T) {
+		p, err := NewStrict("")
+		require.NoError(t, err)
+
+		require.ErrorIs(t, p.UnmarshalText([]byte("/a~2")), ErrInvalidEscape)
+	})
+
+	t.Run("should be usable as a flag", func(t *testing.T) {
+		var p Pointer
+
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		fs.TextVar(&p, "pointer", FromTokens("default"), "a json pointer")
+
+		require.NoError(t, fs.Parse([]string{"-pointer", "/obj/a"}))
+		assert.EqualT(t, "/obj/a", p.String())
+
+		require.Error(t, fs.Parse([]string{"-pointer", "obj"}))
+	})
+}
@@ -69,11 +69,131 @@ func WithNameProvider(provider NameProvider) Option {
 	}
 }
 
+// WithCoercion converts the values set by [Pointer.Set] (or added by [ApplyPatch]) to the type of
+// their target, whenever they are not directly assignable.
+//
+// This allows values decoded from JSON to be set into typed go values. Conversions are attempted in
+// this order:
+//
+//   - numbers are converted to any numeric type, provided the conversion is exact: e.g. a float64
+//     2.0 may be set into an int32 field, but 2.5 or 1e10 may not
+//   - pointers are dereferenced or allocated as needed: e.g. a string may be set into a *string
+//     field
+//   - other values are converted by a JSON round trip: e.g. a map[string]any may be set into a
+//     struct field
+//
+// By default, values must be assignable to their target.
+func WithCoercion() Option {
+	return func(o *options) {
+		o.coerce = true
+	}
+}
+
+// WithCreateMissing creates the missing intermediate containers of the location set by
+// [Pointer.Set], like "mkdir -p" does for directories.
+//
+// Missing (or nil) intermediate values are created empty, with the type expected by their parent:
+//
+//   - a map, a slice or a new struct for typed go values (e.g. a nil struct pointer field)
+//   - a []any when the parent accepts any value and the next token is "0" or "-", or a
+//     map[string]any otherwise
+//
+// In addition, a terminal array index equal to the length of the slice appends the value, like the
+// "-" token does.
+//
+// This allows documents to be built from a flat list of pointer/value pairs.
+func WithCreateMissing() Option {
+	return func(o *options) {
+		o.createMissing = true
+	}
+}
+
+// WithReplaceOnly only allows [Pointer.Set] to replace existing values, like the RFC 6902
+// "replace" operation.
+//
+// Setting a map entry that does not exist, or appending to a slice, fails with an error wrapping
+// [ErrPointer], instead of silently adding a new value.
+//
+// Struct fields and existing slice elements are considered existing values.
+//
+// WithReplaceOnly and [WithAddOnly] are mutually exclusive: the last one applied prevails.
+func WithReplaceOnly() Option {
+	return func(o *options) {
+		o.setMode = setModeReplaceOnly
+	}
+}
+
+// WithAddOnly only allows [Pointer.Set] to add new values, and is the complement of
+// [WithReplaceOnly].
+//
+// Setting a map entry that already exists, a struct field or an existing slice element fails with
+// an error wrapping [ErrAlreadyExists] and [ErrPointer]. Appending to a slice is allowed.
+//
+// WithAddOnly and [WithReplaceOnly] are mutually exclusive: the last one applied prevails.
+func WithAddOnly() Option {
+	return func(o *options) {
+		o.setMode = setModeAddOnly
+	}
+}
+
+// WithSortedKeys makes [Walk] visit the keys of objects in a deterministic order.
+//
+// Keys are sorted like by [Pointer.Compare], so that pointers are yielded in this order. This
+// applies to map keys as well as to struct fields, which are otherwise visited in declaration
+// order.
+func WithSortedKeys() Option {
+	return func(o *options) {
+		o.sortKeys = true
+	}
+}
+
+// WithMaxDepth limits [Walk] to the locations referenced by pointers with at most depth tokens.
+//
+// Values found at the maximum depth are yielded, but their children are not visited. A depth of 0
+// or less means no limit, which is the default.
+func WithMaxDepth(depth int) Option {
+	return func(o *options) {
+		o.maxDepth = depth
+	}
+}
+
+// WithLeavesOnly makes [Walk] yield only leaves, i.e. the values that have no children to visit,
+// such as scalars, nil values and empty containers.
+//
+// By default, all values are yielded, including containers.
+func WithLeavesOnly() Option {
+	return func(o *options) {
+		o.leavesOnly = true
+	}
+}
+
+// setMode restricts the values that may be set.
+type setMode uint8
+
+const (
+	setModeAny setMode = iota
+	setModeReplaceOnly
+	setModeAddOnly
+)
+
 type options struct {
-	provider NameProvider
+	provider      NameProvider
+	strict        bool
+	coerce        bool
+	createMissing bool
+	setMode       setMode
+	fields        *fieldCache
+	sortKeys      bool
+	maxDepth      int
+	leavesOnly    bool
 }
 
 func optionsWithDefaults(opts []Option) options {
+	if len(opts) == 0 {
+		// avoids moving o to the heap when no option is applied
+		return options{provider: DefaultNameProvider()}
+	}
+
 	var o options
 	o.provider = DefaultNameProvider()
 
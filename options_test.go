@@ -147,3 +147,213 @@ func TestDefaultNameProvider_reachesGetForToken(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "hello", v)
 }
+
+type createMissingLeaf struct {
+	Value int `json:"value"`
+}
+
+type createMissingDoc struct {
+	Ptr    *createMissingLeaf           `json:"ptr"`
+	Leaves map[string]createMissingLeaf `json:"leaves"`
+	List   []int                        `json:"list"`
+	Items  []createMissingLeaf          `json:"items"`
+	Any    any                          `json:"any"`
+}
+
+func TestWithCreateMissing(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should build a document from pointer/value pairs", func(t *testing.T) {
+		doc := map[string]any{}
+
+		for _, set := range []struct {
+			pointer string
+			value   any
+		}{
+			{pointer: "/a/b/c", value: 1},
+			{pointer: "/a/b/d", value: 2},
+			{pointer: "/a/list/0", value: "x"},
+			{pointer: "/a/list/1", value: "y"},
+			{pointer: "/a/list/-", value: "z"},
+			{pointer: "/a/objects/0/name", value: "n"},
+			{pointer: "/a/objects/-/name", value: "m"},
+		} {
+			p, err := New(set.pointer)
+			require.NoError(t, err)
+
+			_, err = p.Set(doc, set.value, WithCreateMissing())
+			require.NoError(t, err, "pointer: %q", set.pointer)
+		}
+
+		assert.Equal(t, map[string]any{
+			"a": map[string]any{
+				"b":    map[string]any{"c": 1, "d": 2},
+				"list": []any{"x", "y", "z"},
+				"objects": []any{
+					map[string]any{"name": "n"},
+					map[string]any{"name": "m"},
+				},
+			},
+		}, doc)
+	})
+
+	t.Run("should allocate typed values", func(t *testing.T) {
+		doc := &createMissingDoc{}
+
+		for _, set := range []struct {
+			pointer string
+			value   any
+		}{
+			{pointer: "/ptr/value", value: 1},
+			{pointer: "/leaves/k/value", value: 2},
+			{pointer: "/list/0", value: 3},
+			{pointer: "/any/x", value: 4},
+			{pointer: "/items/-/value", value: 5},
+		} {
+			p, err := New(set.pointer)
+			require.NoError(t, err)
+
+			_, err = p.Set(doc, set.value, WithCreateMissing())
+			require.NoError(t, err, "pointer: %q", set.pointer)
+		}
+
+		require.NotNil(t, doc.Ptr)
+		assert.EqualT(t, 1, doc.Ptr.Value)
+		assert.Equal(t, map[string]createMissingLeaf{"k": {Value: 2}}, doc.Leaves)
+		assert.Equal(t, []int{3}, doc.List)
+		assert.Equal(t, map[string]any{"x": 4}, doc.Any)
+		assert.Equal(t, []createMissingLeaf{{Value: 5}}, doc.Items)
+	})
+
+	t.Run("should not create anything on error", func(t *testing.T) {
+		doc := &createMissingDoc{}
+
+		p, err := New("/ptr/bogus")
+		require.NoError(t, err)
+
+		_, err = p.Set(doc, 1, WithCreateMissing())
+		require.Error(t, err)
+		require.ErrorContains(t, err, `no field "bogus"`)
+		assert.Nil(t, doc.Ptr)
+	})
+
+	t.Run("should still report out of bounds indices", func(t *testing.T) {
+		doc := map[string]any{"list": []any{}}
+
+		p, err := New("/list/1")
+		require.NoError(t, err)
+
+		_, err = p.Set(doc, 1, WithCreateMissing())
+		require.Error(t, err)
+		require.ErrorContains(t, err, "out of bounds")
+	})
+
+	t.Run("should not create missing values by default", func(t *testing.T) {
+		p, err := New("/a/b")
+		require.NoError(t, err)
+
+		_, err = p.Set(map[string]any{}, 1)
+		require.Error(t, err)
+		require.ErrorContains(t, err, `no key "a"`)
+	})
+}
+
+func TestWithReplaceOnly(t *testing.T) {
+	t.Parallel()
+
+	newDoc := func() map[string]any {
+		return map[string]any{
+			"m":     map[string]any{"k": 1},
+			"typed": map[string]int{"k": 1},
+			"arr":   []any{1},
+			"s":     &testStructJSON{},
+		}
+	}
+
+	t.Run("should replace existing values", func(t *testing.T) {
+		for _, pointer := range []string{"/m/k", "/typed/k", "/arr/0", "/s/obj/a"} {
+			p, err := New(pointer)
+			require.NoError(t, err)
+
+			_, err = p.Set(newDoc(), 2, WithReplaceOnly())
+			require.NoError(t, err, "pointer: %q", pointer)
+		}
+	})
+
+	t.Run("should refuse to add values", func(t *testing.T) {
+		for _, pointer := range []string{"/m/typo", "/typed/typo", "/arr/-", "/typo"} {
+			p, err := New(pointer)
+			require.NoError(t, err)
+
+			doc := newDoc()
+			_, err = p.Set(doc, 2, WithReplaceOnly())
+			require.Error(t, err, "pointer: %q", pointer)
+			require.ErrorIs(t, err, ErrPointer)
+			require.ErrorContains(t, err, "no such value")
+			assert.Equal(t, newDoc(), doc)
+		}
+	})
+
+	t.Run("should report errors other than missing values first", func(t *testing.T) {
+		p, err := New("/arr/3")
+		require.NoError(t, err)
+
+		_, err = p.Set(newDoc(), 2, WithReplaceOnly())
+		require.ErrorContains(t, err, "out of bounds")
+	})
+}
+
+func TestWithAddOnly(t *testing.T) {
+	t.Parallel()
+
+	newDoc := func() map[string]any {
+		return map[string]any{
+			"m":     map[string]any{"k": 1},
+			"typed": map[string]int{"k": 1},
+			"arr":   []any{1},
+			"ints":  []int{1},
+			"s":     &testStructJSON{},
+		}
+	}
+
+	t.Run("should add new values", func(t *testing.T) {
+		for _, pointer := range []string{"/m/new", "/typed/new", "/arr/-", "/ints/-", "/new"} {
+			p, err := New(pointer)
+			require.NoError(t, err)
+
+			_, err = p.Set(newDoc(), 2, WithAddOnly())
+			require.NoError(t, err, "pointer: %q", pointer)
+		}
+
+		p, err := New("/ints/0")
+		require.NoError(t, err)
+
+		doc := newDoc()
+		_, err = p.Insert(doc, 0, WithAddOnly())
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 1}, doc["ints"])
+	})
+
+	t.Run("should refuse to replace values", func(t *testing.T) {
+		for _, pointer := range []string{"/m/k", "/typed/k", "/arr/0", "/ints/0", "/s/obj/a"} {
+			p, err := New(pointer)
+			require.NoError(t, err)
+
+			doc := newDoc()
+			_, err = p.Set(doc, 2, WithAddOnly())
+			require.Error(t, err, "pointer: %q", pointer)
+			require.ErrorIs(t, err, ErrPointer)
+			require.ErrorIs(t, err, ErrAlreadyExists)
+			require.ErrorContains(t, err, "already exists")
+			assert.Equal(t, newDoc(), doc)
+		}
+	})
+
+	t.Run("should let the last option prevail", func(t *testing.T) {
+		p, err := New("/m/k")
+		require.NoError(t, err)
+
+		_, err = p.Set(newDoc(), 2, WithAddOnly(), WithReplaceOnly())
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,406 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpointer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/go-openapi/jsonpointer/internal/identity"
+)
+
+// Operation names of an RFC 6902 JSON Patch.
+const (
+	OpAdd     = "add"
+	OpRemove  = "remove"
+	OpReplace = "replace"
+	OpMove    = "move"
+	OpCopy    = "copy"
+	OpTest    = "test"
+)
+
+// Patch is an RFC 6902 JSON Patch document, i.e. an ordered list of operations.
+//
+// Use [DecodePatch] to parse a patch from its JSON representation and [ApplyPatch] to apply it to a
+// document.
+type Patch []Operation
+
+// Operation is a single RFC 6902 JSON Patch operation.
+//
+// Path and From are JSON pointers in their string representation.
+//
+// From is only used by "move" and "copy" operations. Value is only used by "add", "replace" and
+// "test" operations.
+type Operation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	From  string `json:"from,omitempty"`
+	Value any    `json:"value"`
+}
+
+// PatchError records the failure of an operation of a [Patch], when applied by [ApplyPatch].
+//
+// It may be retrieved with [errors.As]. Like all errors from this package, it matches [ErrPointer].
+type PatchError struct {
+	// Index is the index of the failing operation in the patch.
+	Index int
+
+	// Op is the failing operation.
+	Op Operation
+
+	// Cause is the underlying error.
+	Cause error
+}
+
+func (e *PatchError) Error() string {
+	return fmt.Sprintf("patch operation %d (%s %q): %v", e.Index, e.Op.Op, e.Op.Path, e.Cause)
+}
+
+// Unwrap returns the underlying error.
+func (e *PatchError) Unwrap() error {
+	return e.Cause
+}
+
+// Is tells that a [PatchError] is an [ErrPointer].
+func (e *PatchError) Is(target error) bool {
+	return target == ErrPointer
+}
+
+// rawOperation is used to decode an operation while keeping track of missing members.
+type rawOperation struct {
+	Op    *string         `json:"op"`
+	Path  *string         `json:"path"`
+	From  *string         `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// DecodePatch parses the JSON representation of an RFC 6902 JSON Patch.
+//
+// Each operation is checked for the members required by its "op". Values are decoded like
+// [encoding/json.Unmarshal] does into an any.
+//
+// Errors wrap [ErrInvalidPatch] and [ErrPointer].
+func DecodePatch(data []byte) (Patch, error) {
+	var raw []rawOperation
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, errors.Join(err, ErrInvalidPatch, ErrPointer)
+	}
+
+	patch := make(Patch, 0, len(raw))
+	for i, r := range raw {
+		op, err := r.decode()
+		if err != nil {
+			return nil, fmt.Errorf("patch operation %d: %w", i, err)
+		}
+
+		patch = append(patch, op)
+	}
+
+	return patch, nil
+}
+
+func (r rawOperation) decode() (Operation, error) {
+	var op Operation
+
+	if r.Op == nil {
+		return op, errMissingMember("op")
+	}
+	op.Op = *r.Op
+
+	if r.Path == nil {
+		return op, errMissingMember("path")
+	}
+	op.Path = *r.Path
+
+	switch op.Op {
+	case OpAdd, OpReplace, OpTest:
+		if r.Value == nil {
+			return op, errMissingMember("value")
+		}
+
+		if err := json.Unmarshal(r.Value, &op.Value); err != nil {
+			return op, errors.Join(err, ErrInvalidPatch, ErrPointer)
+		}
+	case OpMove, OpCopy:
+		if r.From == nil {
+			return op, errMissingMember("from")
+		}
+		op.From = *r.From
+	case OpRemove:
+	default:
+		return op, errUnknownOperation(op.Op)
+	}
+
+	return op, nil
+}
+
+// ApplyPatch applies an RFC 6902 JSON Patch to a document.
+//
+// The document may be any go value supported by [Pointer.Get] and [Pointer.Set]. Operations are
+// applied in order, with the following semantics:
+//
+//   - "add" sets a map key or a struct field, inserts into a slice at a numeric index (shifting
+//     subsequent elements) or appends to a slice with the "-" token
+//   - "remove" deletes a map key, splices a slice element or resets a struct field to its zero value
+//   - "replace" sets a value that must already exist
+//   - "move" and "copy" take the value found at "from" and add it at "path". The copied value is
+//     a deep copy of the original
+//   - "test" compares the value found at "path" with the expected value, after normalizing both
+//     to their JSON representation
+//
+// The "path" and "from" members are parsed like by [NewStrict]: array indices such as "01", "+1"
+// or "-0" are rejected.
+//
+// An operation targeting the empty pointer "" replaces (or tests) the whole document.
+//
+// # Mutation contract
+//
+// Like [Pointer.Set], ApplyPatch mutates the document in place whenever possible, and the returned
+// document is only load-bearing when that is not possible (e.g. the length of a top-level slice
+// passed by value changes, or the root document is replaced).
+//
+// The patch is not applied atomically: when an operation fails, the operations before it may have
+// already mutated the document. Apply the patch to a copy if you need to roll back.
+//
+// # Errors
+//
+// The returned error is a *[PatchError], which records the index of the failing operation. All errors
+// wrap [ErrPointer].
+func ApplyPatch(document any, patch Patch, opts ...Option) (any, error) {
+	o := optionsWithDefaults(opts)
+
+	for i, op := range patch {
+		var err error
+
+		document, err = op.apply(document, o)
+		if err != nil {
+			return document, &PatchError{Index: i, Op: op, Cause: err}
+		}
+	}
+
+	return document, nil
+}
+
+func (op Operation) apply(document any, o options) (any, error) {
+	path, err := NewStrict(op.Path)
+	if err != nil {
+		return document, err
+	}
+
+	switch op.Op {
+	case OpAdd:
+		return addValue(document, path, op.Value, o)
+
+	case OpRemove:
+		return path.remove(document, o)
+
+	case OpReplace:
+		if _, _, err := path.get(document, o); err != nil {
+			return document, err
+		}
+
+		if path.IsEmpty() {
+			return op.Value, nil
+		}
+
+		return path.set(document, op.Value, o)
+
+	case OpMove:
+		from, err := NewStrict(op.From)
+		if err != nil {
+			return document, err
+		}
+
+		value, _, err := from.get(document, o)
+		if err != nil {
+			return document, err
+		}
+
+		if from.Equal(path) {
+			return document, nil
+		}
+
+		if from.IsAncestorOf(path) {
+			return document, fmt.Errorf("cannot move %q into one of its children: %w: %w", op.From, ErrInvalidPatch, ErrPointer)
+		}
+
+		document, err = from.remove(document, o)
+		if err != nil {
+			return document, err
+		}
+
+		return addValue(document, path, value, o)
+
+	case OpCopy:
+		from, err := NewStrict(op.From)
+		if err != nil {
+			return document, err
+		}
+
+		value, _, err := from.get(document, o)
+		if err != nil {
+			return document, err
+		}
+
+		return addValue(document, path, deepCopy(value), o)
+
+	case OpTest:
+		value, _, err := path.get(document, o)
+		if err != nil {
+			return document, err
+		}
+
+		if !jsonEqual(value, op.Value) {
+			return document, fmt.Errorf("value at %q does not match: %w: %w", op.Path, ErrPatchTest, ErrPointer)
+		}
+
+		return document, nil
+
+	default:
+		return document, errUnknownOperation(op.Op)
+	}
+}
+
+func addValue(document any, path Pointer, value any, o options) (any, error) {
+	if path.IsEmpty() {
+		return value, nil
+	}
+
+	return path.insert(document, value, o)
+}
+
+// jsonEqual tells if two values have the same JSON representation, regardless of the ordering of
+// object keys and of the go types used to represent numbers.
+func jsonEqual(a, b any) bool {
+	na, err := normalizeJSON(a)
+	if err != nil {
+		return false
+	}
+
+	nb, err := normalizeJSON(b)
+	if err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(na, nb)
+}
+
+func normalizeJSON(value any) (any, error) {
+	buf, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var normalized any
+	if err := json.Unmarshal(buf, &normalized); err != nil {
+		return nil, err
+	}
+
+	return normalized, nil
+}
+
+// deepCopy returns a copy of value that shares no map, slice or pointer with the original.
+//
+// Maps, slices and pointers shared by several locations of the value are copied once, so that the copy
+// preserves sharing and cycles. Unexported struct fields are copied shallowly.
+func deepCopy(value any) any {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() {
+		return value
+	}
+
+	c := copier{copies: make(map[identity.Key]reflect.Value)}
+
+	return c.copyValue(v).Interface()
+}
+
+// copier deep-copies values, remembering the copies already made for maps, slices and pointers.
+type copier struct {
+	copies map[identity.Key]reflect.Value
+}
+
+func (c *copier) copyValue(v reflect.Value) reflect.Value {
+	key, tracked := identity.OfValue(v)
+	if tracked {
+		if cp, done := c.copies[key]; done {
+			return cp
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+
+		cp := reflect.MakeMapWithSize(v.Type(), v.Len())
+		c.copies[key] = cp
+		iter := v.MapRange()
+		for iter.Next() {
+			cp.SetMapIndex(iter.Key(), c.copyValue(iter.Value()))
+		}
+
+		return cp
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+
+		cp := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		if tracked {
+			c.copies[key] = cp
+		}
+		for i := range v.Len() {
+			cp.Index(i).Set(c.copyValue(v.Index(i)))
+		}
+
+		return cp
+
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+
+		cp := reflect.New(v.Type().Elem())
+		c.copies[key] = cp
+		cp.Elem().Set(c.copyValue(v.Elem()))
+
+		return cp
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(c.copyValue(v.Elem()))
+
+		return cp
+
+	case reflect.Struct:
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(v)
+		for i := range cp.NumField() {
+			if fld := cp.Field(i); fld.CanSet() {
+				fld.Set(c.copyValue(fld))
+			}
+		}
+
+		return cp
+
+	case reflect.Array:
+		cp := reflect.New(v.Type()).Elem()
+		for i := range v.Len() {
+			cp.Index(i).Set(c.copyValue(v.Index(i)))
+		}
+
+		return cp
+
+	default:
+		return v
+	}
+}
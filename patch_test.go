@@ -0,0 +1,383 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpointer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+// RFC 6902 Appendix A: examples.
+func TestApplyPatch_RFC6902Examples(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		doc      string
+		patch    string
+		expected string
+		fails    bool
+	}{
+		{
+			name:     "A.1. adding an object member",
+			doc:      `{"foo": "bar"}`,
+			patch:    `[{"op": "add", "path": "/baz", "value": "qux"}]`,
+			expected: `{"baz": "qux", "foo": "bar"}`,
+		},
+		{
+			name:     "A.2. adding an array element",
+			doc:      `{"foo": ["bar", "baz"]}`,
+			patch:    `[{"op": "add", "path": "/foo/1", "value": "qux"}]`,
+			expected: `{"foo": ["bar", "qux", "baz"]}`,
+		},
+		{
+			name:     "A.3. removing an object member",
+			doc:      `{"baz": "qux", "foo": "bar"}`,
+			patch:    `[{"op": "remove", "path": "/baz"}]`,
+			expected: `{"foo": "bar"}`,
+		},
+		{
+			name:     "A.4. removing an array element",
+			doc:      `{"foo": ["bar", "qux", "baz"]}`,
+			patch:    `[{"op": "remove", "path": "/foo/1"}]`,
+			expected: `{"foo": ["bar", "baz"]}`,
+		},
+		{
+			name:     "A.5. replacing a value",
+			doc:      `{"baz": "qux", "foo": "bar"}`,
+			patch:    `[{"op": "replace", "path": "/baz", "value": "boo"}]`,
+			expected: `{"baz": "boo", "foo": "bar"}`,
+		},
+		{
+			name:     "A.6. moving a value",
+			doc:      `{"foo": {"bar": "baz", "waldo": "fred"}, "qux": {"corge": "grault"}}`,
+			patch:    `[{"op": "move", "from": "/foo/waldo", "path": "/qux/thud"}]`,
+			expected: `{"foo": {"bar": "baz"}, "qux": {"corge": "grault", "thud": "fred"}}`,
+		},
+		{
+			name:     "A.7. moving an array element",
+			doc:      `{"foo": ["all", "grass", "cows", "eat"]}`,
+			patch:    `[{"op": "move", "from": "/foo/1", "path": "/foo/3"}]`,
+			expected: `{"foo": ["all", "cows", "eat", "grass"]}`,
+		},
+		{
+			name:     "A.8. testing a value: success",
+			doc:      `{"baz": "qux", "foo": ["a", 2, "c"]}`,
+			patch:    `[{"op": "test", "path": "/baz", "value": "qux"}, {"op": "test", "path": "/foo/1", "value": 2}]`,
+			expected: `{"baz": "qux", "foo": ["a", 2, "c"]}`,
+		},
+		{
+			name:  "A.9. testing a value: error",
+			doc:   `{"baz": "qux"}`,
+			patch: `[{"op": "test", "path": "/baz", "value": "bar"}]`,
+			fails: true,
+		},
+		{
+			name:     "A.10. adding a nested member object",
+			doc:      `{"foo": "bar"}`,
+			patch:    `[{"op": "add", "path": "/child", "value": {"grandchild": {}}}]`,
+			expected: `{"foo": "bar", "child": {"grandchild": {}}}`,
+		},
+		{
+			name:     "A.11. ignoring unrecognized elements",
+			doc:      `{"foo": "bar"}`,
+			patch:    `[{"op": "add", "path": "/baz", "value": "qux", "xyz": 123}]`,
+			expected: `{"foo": "bar", "baz": "qux"}`,
+		},
+		{
+			name:  "A.12. adding to a nonexistent target",
+			doc:   `{"foo": "bar"}`,
+			patch: `[{"op": "add", "path": "/baz/bat", "value": "qux"}]`,
+			fails: true,
+		},
+		{
+			name:     "A.14. ~ escape ordering",
+			doc:      `{"/": 9, "~1": 10}`,
+			patch:    `[{"op": "test", "path": "/~01", "value": 10}]`,
+			expected: `{"/": 9, "~1": 10}`,
+		},
+		{
+			name:  "A.15. comparing strings and numbers",
+			doc:   `{"/": 9, "~1": 10}`,
+			patch: `[{"op": "test", "path": "/~01", "value": "10"}]`,
+			fails: true,
+		},
+		{
+			name:     "A.16. adding an array value",
+			doc:      `{"foo": ["bar"]}`,
+			patch:    `[{"op": "add", "path": "/foo/-", "value": ["abc", "def"]}]`,
+			expected: `{"foo": ["bar", ["abc", "def"]]}`,
+		},
+		{
+			name:     "copying a value",
+			doc:      `{"foo": {"bar": [1, 2]}}`,
+			patch:    `[{"op": "copy", "from": "/foo", "path": "/baz"}, {"op": "add", "path": "/baz/bar/-", "value": 3}]`,
+			expected: `{"foo": {"bar": [1, 2]}, "baz": {"bar": [1, 2, 3]}}`,
+		},
+		{
+			name:     "adding at the end of an array",
+			doc:      `[1, 2]`,
+			patch:    `[{"op": "add", "path": "/2", "value": 3}]`,
+			expected: `[1, 2, 3]`,
+		},
+		{
+			name:     "replacing the whole document",
+			doc:      `{"foo": "bar"}`,
+			patch:    `[{"op": "replace", "path": "", "value": [1]}]`,
+			expected: `[1]`,
+		},
+		{
+			name:     "moving a value onto itself",
+			doc:      `{"foo": {"bar": 1}}`,
+			patch:    `[{"op": "move", "from": "/foo", "path": "/foo"}]`,
+			expected: `{"foo": {"bar": 1}}`,
+		},
+		{
+			name:  "moving a value into one of its children",
+			doc:   `{"foo": {"bar": 1}}`,
+			patch: `[{"op": "move", "from": "/foo", "path": "/foo/bar/baz"}]`,
+			fails: true,
+		},
+		{
+			name:  "replacing a nonexistent value",
+			doc:   `{"foo": "bar"}`,
+			patch: `[{"op": "replace", "path": "/baz", "value": "qux"}]`,
+			fails: true,
+		},
+		{
+			name:  "removing a nonexistent value",
+			doc:   `{"foo": "bar"}`,
+			patch: `[{"op": "remove", "path": "/baz"}]`,
+			fails: true,
+		},
+		{
+			name:  "adding past the end of an array",
+			doc:   `[1, 2]`,
+			patch: `[{"op": "add", "path": "/3", "value": 3}]`,
+			fails: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc any
+			require.NoError(t, json.Unmarshal([]byte(tt.doc), &doc))
+
+			patch, err := DecodePatch([]byte(tt.patch))
+			require.NoError(t, err)
+
+			result, err := ApplyPatch(doc, patch)
+			if tt.fails {
+				require.Error(t, err)
+				require.ErrorIs(t, err, ErrPointer)
+
+				return
+			}
+
+			require.NoError(t, err)
+			actual, err := json.Marshal(result)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(actual))
+		})
+	}
+}
+
+func TestDecodePatch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should decode a patch", func(t *testing.T) {
+		patch, err := DecodePatch([]byte(`[
+			{"op": "add", "path": "/a", "value": null},
+			{"op": "copy", "from": "/a", "path": "/b"}
+		]`))
+		require.NoError(t, err)
+		assert.Equal(t, Patch{
+			{Op: OpAdd, Path: "/a"},
+			{Op: OpCopy, From: "/a", Path: "/b"},
+		}, patch)
+	})
+
+	for _, input := range []string{
+		`{"op": "add"}`,
+		`[{"path": "/a"}]`,
+		`[{"op": "add", "value": 1}]`,
+		`[{"op": "add", "path": "/a"}]`,
+		`[{"op": "move", "path": "/a"}]`,
+		`[{"op": "unknown", "path": "/a"}]`,
+	} {
+		t.Run("should reject invalid patch "+input, func(t *testing.T) {
+			_, err := DecodePatch([]byte(input))
+			require.Error(t, err)
+			require.ErrorIs(t, err, ErrInvalidPatch)
+			require.ErrorIs(t, err, ErrPointer)
+		})
+	}
+}
+
+func TestApplyPatch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should report the index of the failing operation", func(t *testing.T) {
+		doc := map[string]any{"foo": "bar"}
+		patch := Patch{
+			{Op: OpTest, Path: "/foo", Value: "bar"},
+			{Op: OpTest, Path: "/foo", Value: "baz"},
+		}
+
+		_, err := ApplyPatch(doc, patch)
+		require.Error(t, err)
+		require.ErrorIs(t, err, ErrPatchTest)
+		require.ErrorIs(t, err, ErrPointer)
+		require.ErrorContains(t, err, `patch operation 1 (test "/foo")`)
+
+		var patchErr *PatchError
+		require.ErrorAs(t, err, &patchErr)
+		assert.EqualT(t, 1, patchErr.Index)
+		assert.EqualT(t, patch[1].Op, patchErr.Op.Op)
+		assert.EqualT(t, patch[1].Path, patchErr.Op.Path)
+		require.ErrorIs(t, patchErr.Cause, ErrPatchTest)
+	})
+
+	t.Run("should report a failing operation with a structured pointer error", func(t *testing.T) {
+		_, err := ApplyPatch(map[string]any{}, Patch{{Op: OpRemove, Path: "/a"}})
+
+		var patchErr *PatchError
+		require.ErrorAs(t, err, &patchErr)
+		assert.EqualT(t, 0, patchErr.Index)
+
+		var pointerErr *PointerError
+		require.ErrorAs(t, err, &pointerErr)
+		assert.EqualT(t, "delete", pointerErr.Op)
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("should reject an invalid pointer", func(t *testing.T) {
+		_, err := ApplyPatch(map[string]any{}, Patch{{Op: OpAdd, Path: "a"}})
+		require.Error(t, err)
+		require.ErrorIs(t, err, ErrInvalidStart)
+	})
+
+	t.Run("should reject an unknown operation", func(t *testing.T) {
+		_, err := ApplyPatch(map[string]any{}, Patch{{Op: "merge", Path: "/a"}})
+		require.Error(t, err)
+		require.ErrorIs(t, err, ErrInvalidPatch)
+	})
+
+	t.Run("should refuse to remove the root document", func(t *testing.T) {
+		_, err := ApplyPatch(map[string]any{}, Patch{{Op: OpRemove, Path: ""}})
+		require.Error(t, err)
+		require.ErrorIs(t, err, ErrPointer)
+	})
+
+	t.Run("should patch a go struct", func(t *testing.T) {
+		type item struct {
+			Name string `json:"name"`
+		}
+		type doc struct {
+			Items []item          `json:"items"`
+			Tags  map[string]bool `json:"tags"`
+			Count int             `json:"count"`
+		}
+
+		d := &doc{
+			Items: []item{{Name: "a"}, {Name: "c"}},
+			Tags:  map[string]bool{"x": true},
+			Count: 3,
+		}
+
+		_, err := ApplyPatch(d, Patch{
+			{Op: OpAdd, Path: "/items/1", Value: item{Name: "b"}},
+			{Op: OpAdd, Path: "/items/-", Value: item{Name: "d"}},
+			{Op: OpRemove, Path: "/items/0"},
+			{Op: OpMove, From: "/tags/x", Path: "/tags/y"},
+			{Op: OpRemove, Path: "/count"},
+			{Op: OpTest, Path: "/items", Value: []any{
+				map[string]any{"name": "b"},
+				map[string]any{"name": "c"},
+				map[string]any{"name": "d"},
+			}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []item{{Name: "b"}, {Name: "c"}, {Name: "d"}}, d.Items)
+		assert.Equal(t, map[string]bool{"y": true}, d.Tags)
+		assert.EqualT(t, 0, d.Count)
+	})
+
+	t.Run("should return the new slice header for a top-level slice passed by value", func(t *testing.T) {
+		doc := []int{1, 2, 3}
+
+		out, err := ApplyPatch(doc, Patch{
+			{Op: OpRemove, Path: "/0"},
+			{Op: OpAdd, Path: "/0", Value: 5},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []int{5, 2, 3}, out)
+		assert.Equal(t, []int{1, 2, 3}, doc, "original backing array should be left untouched")
+	})
+
+	t.Run("should reject non-canonical array indices", func(t *testing.T) {
+		for _, index := range []string{"01", "+1", "-0"} {
+			doc := map[string]any{"a": []any{1, 2}}
+
+			_, err := ApplyPatch(doc, Patch{{Op: OpAdd, Path: "/a/" + index, Value: 3}})
+			require.ErrorIs(t, err, ErrInvalidIndex)
+			require.ErrorIs(t, err, ErrPointer)
+
+			_, err = ApplyPatch(doc, Patch{{Op: OpCopy, From: "/a/" + index, Path: "/b"}})
+			require.ErrorIs(t, err, ErrInvalidIndex)
+
+			assert.Equal(t, map[string]any{"a": []any{1, 2}}, doc)
+		}
+	})
+
+	t.Run("copy should not alias the source value", func(t *testing.T) {
+		doc := map[string]any{"a": map[string]any{"b": []any{1}}}
+
+		_, err := ApplyPatch(doc, Patch{
+			{Op: OpCopy, From: "/a", Path: "/c"},
+			{Op: OpReplace, Path: "/c/b/0", Value: 2},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"a": map[string]any{"b": []any{1}},
+			"c": map[string]any{"b": []any{2}},
+		}, doc)
+	})
+
+	t.Run("copy should preserve cycles in the source value", func(t *testing.T) {
+		type node struct {
+			Name string `json:"name"`
+			Next *node  `json:"next"`
+		}
+
+		n := &node{Name: "n"}
+		n.Next = n
+		loop := []any{"x", nil}
+		loop[1] = loop
+		doc := map[string]any{"n": n, "loop": loop}
+
+		_, err := ApplyPatch(doc, Patch{
+			{Op: OpCopy, From: "/n", Path: "/m"},
+			{Op: OpCopy, From: "/loop", Path: "/loop2"},
+		})
+		require.NoError(t, err)
+
+		m, ok := doc["m"].(*node)
+		require.True(t, ok)
+		assert.NotSame(t, n, m)
+		assert.Same(t, m, m.Next)
+		assert.EqualT(t, "n", m.Name)
+
+		loop2, ok := doc["loop2"].([]any)
+		require.True(t, ok)
+		require.Len(t, loop2, 2)
+		inner, ok := loop2[1].([]any)
+		require.True(t, ok)
+		assert.Same(t, &loop2[0], &inner[0])
+		assert.NotSame(t, &loop[0], &loop2[0])
+	})
+}
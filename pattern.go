@@ -0,0 +1,217 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpointer
+
+import (
+	"iter"
+	"slices"
+)
+
+const (
+	anyTokenSegment = "*"
+	anyDepthSegment = "**"
+)
+
+// Pattern is a json pointer with wildcard segments, which matches several locations of a document.
+//
+// On top of the json pointer syntax, a pattern accepts the following segments:
+//
+//   - "*" matches any single token
+//   - "**" matches any number of tokens, including none
+//
+// For instance, the pattern "/paths/*/*/responses/*/description" matches the descriptions of all
+// the responses of all the operations of an OpenAPI document, and "/**/description" matches all the
+// "description" keys at any depth.
+//
+// Since a pattern is parsed like a [Pointer], tokens are escaped with "~0" and "~1". There is no way
+// to escape a wildcard: a "*" or "**" segment is always a wildcard, which also matches a key that is
+// literally "*" or "**".
+//
+// Like pointers, patterns are immutable and may be safely shared between goroutines.
+type Pattern struct {
+	pointer  Pointer
+	segments []string // decoded tokens
+}
+
+// NewPattern creates a new pattern from its string representation.
+//
+// It fails with an error wrapping [ErrPointer] if the pattern is not a valid json pointer.
+func NewPattern(pattern string) (Pattern, error) {
+	pointer, err := New(pattern)
+	if err != nil {
+		return Pattern{}, err
+	}
+
+	return Pattern{
+		pointer:  pointer,
+		segments: pointer.DecodedTokens(),
+	}, nil
+}
+
+// String representation of the pattern.
+func (p Pattern) String() string {
+	return p.pointer.String()
+}
+
+// Match tells if a pointer matches the pattern.
+func (p Pattern) Match(pointer Pointer) bool {
+	var buf [decodedTokensBufferSize]string
+
+	return p.matchPath(pointer.appendDecodedTokens(buf[:0])).matched
+}
+
+// Find iterates over all the locations of a document that match the pattern, yielding their pointer
+// and value.
+//
+// The document is traversed like by [Walk], which accepts the same options, but only the branches
+// that may match the pattern are visited. Literal tokens are resolved like by [Pointer.Get], so that
+// values implementing [JSONPointable] are supported.
+func (p Pattern) Find(document any, opts ...Option) iter.Seq2[Pointer, any] {
+	return walk(document, &p, opts)
+}
+
+// SetAll sets the value at all the locations of a document that match the pattern, like
+// [Pointer.Set] does for a single location.
+//
+// When the pattern ends with a literal token, e.g. "/items/*/-" or "/*/newKey", this token is set on
+// all the locations that match the rest of the pattern, so that missing keys are added and "-"
+// appends to arrays, like with [Pointer.Set]. This does not apply to a literal token following a
+// "**" segment, since any location would then be a parent: like for a pattern ending with a
+// wildcard, only the existing matching locations are set.
+//
+// Matching locations are found first, then set in reverse order, so that descendants are set before
+// their ancestors. SetAll stops at the first error.
+//
+// Like [Pointer.Set], it returns the updated document, which is load-bearing when the document is
+// a slice passed by value.
+func (p Pattern) SetAll(document any, value any, opts ...Option) (any, error) {
+	var pointers []Pointer
+	if parent, last, ok := p.splitLiteral(); ok {
+		o := optionsWithDefaults(opts)
+		parentOpts := append(slices.Clip(opts), func(o *options) {
+			o.leavesOnly = false // parents have children
+		})
+
+		for pointer := range parent.Find(document, parentOpts...) {
+			if o.maxDepth > 0 && pointer.Len() >= o.maxDepth {
+				continue
+			}
+
+			pointers = append(pointers, pointer.Append(last))
+		}
+	} else {
+		for pointer := range p.Find(document, opts...) {
+			pointers = append(pointers, pointer)
+		}
+	}
+
+	for _, pointer := range slices.Backward(pointers) {
+		updated, err := pointer.Set(document, value, opts...)
+		if err != nil {
+			return document, err
+		}
+
+		document = updated
+	}
+
+	return document, nil
+}
+
+// splitLiteral splits a pattern ending with a literal token into the pattern of its parents and this
+// token.
+//
+// It returns false when the pattern is empty, ends with a wildcard, or with a literal token following
+// a "**" segment.
+func (p Pattern) splitLiteral() (Pattern, string, bool) {
+	n := len(p.segments)
+	if n == 0 {
+		return Pattern{}, "", false
+	}
+
+	last := p.segments[n-1]
+	if last == anyTokenSegment || last == anyDepthSegment || (n > 1 && p.segments[n-2] == anyDepthSegment) {
+		return Pattern{}, "", false
+	}
+
+	return Pattern{pointer: p.pointer.Parent(), segments: p.segments[:n-1]}, last, true
+}
+
+// patternMatch is the state of a pattern matched against the tokens of a path.
+type patternMatch struct {
+	matched  bool     // the path matches the pattern
+	viable   bool     // a descendant of the path may match the pattern
+	wildcard bool     // the next token of a matching descendant may be any token
+	literals []string // the next tokens of a matching descendant, when wildcard is false
+}
+
+// matchPath matches the decoded tokens of a path against the pattern.
+//
+// The pattern is run as a nondeterministic automaton, with a state per segment: the "**" segment
+// may consume any number of tokens, or none.
+func (p *Pattern) matchPath(tokens []string) patternMatch {
+	states := p.closure(make([]int, 0, len(p.segments)+1), 0)
+
+	for _, token := range tokens {
+		next := make([]int, 0, len(p.segments)+1)
+
+		for _, state := range states {
+			if state == len(p.segments) {
+				continue
+			}
+
+			switch segment := p.segments[state]; segment {
+			case anyDepthSegment:
+				next = p.closure(next, state)
+			case anyTokenSegment, token:
+				next = p.closure(next, state+1)
+			}
+		}
+
+		if len(next) == 0 {
+			return patternMatch{}
+		}
+
+		states = next
+	}
+
+	var match patternMatch
+	for _, state := range states {
+		if state == len(p.segments) {
+			match.matched = true
+
+			continue
+		}
+
+		match.viable = true
+
+		switch segment := p.segments[state]; segment {
+		case anyTokenSegment, anyDepthSegment:
+			match.wildcard = true
+		default:
+			if !slices.Contains(match.literals, segment) {
+				match.literals = append(match.literals, segment)
+			}
+		}
+	}
+
+	return match
+}
+
+// closure adds a state to the set of states, along with the states reached by skipping "**"
+// segments.
+func (p *Pattern) closure(states []int, state int) []int {
+	for {
+		if slices.Contains(states, state) {
+			return states
+		}
+
+		states = append(states, state)
+
+		if state == len(p.segments) || p.segments[state] != anyDepthSegment {
+			return states
+		}
+
+		state++
+	}
+}
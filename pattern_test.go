@@ -0,0 +1,299 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpointer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func mustPattern(t *testing.T, pattern string) Pattern {
+	t.Helper()
+
+	p, err := NewPattern(pattern)
+	require.NoError(t, err)
+
+	return p
+}
+
+// foundPointers collects the pointers yielded by Pattern.Find, as strings.
+func foundPointers(pattern Pattern, document any, opts ...Option) []string {
+	var pointers []string
+	for p := range pattern.Find(document, opts...) {
+		pointers = append(pointers, p.String())
+	}
+
+	return pointers
+}
+
+const testPatternDocumentJSON = `{
+  "paths": {
+    "/pets": {
+      "get": {
+        "responses": {
+          "200": {"description": "pets"},
+          "default": {"description": "error"}
+        }
+      },
+      "post": {
+        "responses": {
+          "201": {"description": "created"}
+        }
+      }
+    },
+    "/pets/{id}": {
+      "parameters": [{"name": "id"}],
+      "get": {
+        "responses": {
+          "200": {"description": "pet"}
+        }
+      }
+    }
+  }
+}`
+
+func testPatternDocument(t testing.TB) any {
+	t.Helper()
+
+	var doc any
+	require.NoError(t, json.Unmarshal([]byte(testPatternDocumentJSON), &doc))
+
+	return doc
+}
+
+func TestNewPattern(t *testing.T) {
+	t.Parallel()
+
+	p, err := NewPattern("/paths/*/~1~0/**")
+	require.NoError(t, err)
+	assert.EqualT(t, "/paths/*/~1~0/**", p.String())
+
+	_, err = NewPattern("paths/*")
+	require.ErrorIs(t, err, ErrPointer)
+	require.ErrorIs(t, err, ErrInvalidStart)
+}
+
+func TestPattern_Match(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		pattern string
+		pointer string
+		match   bool
+	}{
+		{pattern: "", pointer: "", match: true},
+		{pattern: "", pointer: "/a", match: false},
+		{pattern: "/a/b", pointer: "/a/b", match: true},
+		{pattern: "/a/b", pointer: "/a/c", match: false},
+		{pattern: "/a/*", pointer: "/a/b", match: true},
+		{pattern: "/a/*", pointer: "/a", match: false},
+		{pattern: "/a/*", pointer: "/a/b/c", match: false},
+		{pattern: "/*/*", pointer: "/a/b", match: true},
+		{pattern: "/a/**", pointer: "/a", match: true},
+		{pattern: "/a/**", pointer: "/a/b/c", match: true},
+		{pattern: "/a/**", pointer: "/b/c", match: false},
+		{pattern: "/**", pointer: "", match: true},
+		{pattern: "/**/c", pointer: "/c", match: true},
+		{pattern: "/**/c", pointer: "/a/b/c", match: true},
+		{pattern: "/**/c", pointer: "/a/c/b", match: false},
+		{pattern: "/**/c/**/c", pointer: "/c/c", match: true},
+		{pattern: "/**/c/**/c", pointer: "/a/c/b/c/d", match: false},
+		{pattern: "/a/**/*", pointer: "/a", match: false},
+		{pattern: "/a/**/*", pointer: "/a/b", match: true},
+		{pattern: "/a~1b/*", pointer: "/a~1b/c", match: true},
+		{pattern: "/a~1b/*", pointer: "/a/b/c", match: false},
+		{pattern: "/*", pointer: "/*", match: true},
+	} {
+		t.Run(tc.pattern+" vs "+tc.pointer, func(t *testing.T) {
+			t.Parallel()
+
+			assert.EqualT(t, tc.match, mustPattern(t, tc.pattern).Match(mustNew(t, tc.pointer)))
+		})
+	}
+}
+
+func TestPattern_Find(t *testing.T) {
+	t.Parallel()
+
+	doc := testPatternDocument(t)
+
+	t.Run("with single token wildcards", func(t *testing.T) {
+		t.Parallel()
+
+		pattern := mustPattern(t, "/paths/*/*/responses/*/description")
+
+		var found []string
+		for p, value := range pattern.Find(doc, WithSortedKeys()) {
+			found = append(found, p.String()+"="+value.(string))
+		}
+
+		assert.Equal(t, []string{
+			"/paths/~1pets/get/responses/200/description=pets",
+			"/paths/~1pets/get/responses/default/description=error",
+			"/paths/~1pets/post/responses/201/description=created",
+			"/paths/~1pets~1{id}/get/responses/200/description=pet",
+		}, found)
+
+		for p := range pattern.Find(doc) {
+			assert.TrueT(t, pattern.Match(p))
+		}
+	})
+
+	t.Run("with any depth wildcards", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t,
+			[]string{"/paths/~1pets~1{id}/parameters/0/name"},
+			foundPointers(mustPattern(t, "/**/name"), doc),
+		)
+		assert.Equal(t,
+			[]string{"/paths/~1pets/get", "/paths/~1pets~1{id}/get"},
+			foundPointers(mustPattern(t, "/paths/**/get"), doc, WithSortedKeys()),
+		)
+	})
+
+	t.Run("with literal tokens only", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t,
+			[]string{"/paths/~1pets/post"},
+			foundPointers(mustPattern(t, "/paths/~1pets/post"), doc),
+		)
+		assert.Empty(t, foundPointers(mustPattern(t, "/paths/missing/*"), doc))
+	})
+
+	t.Run("with leaves only", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t,
+			[]string{"/paths/~1pets~1{id}/parameters/0/name"},
+			foundPointers(mustPattern(t, "/paths/~1pets~1{id}/parameters/**"), doc, WithLeavesOnly()),
+		)
+		// a matching container is not a leaf
+		assert.Empty(t, foundPointers(mustPattern(t, "/paths/*"), doc, WithLeavesOnly()))
+	})
+
+	t.Run("with structs", func(t *testing.T) {
+		t.Parallel()
+
+		doc := testStructJSONDoc(t)
+
+		assert.Equal(t,
+			[]string{"/obj/d/0/f", "/obj/d/1/f/0", "/obj/d/1/f/1"},
+			foundPointers(mustPattern(t, "/obj/d/*/f/**"), doc, WithLeavesOnly()),
+		)
+	})
+
+	t.Run("with JSONPointable", func(t *testing.T) {
+		t.Parallel()
+
+		doc := map[string]any{"p": pointableMap{"swapped": "value"}}
+
+		var found []string
+		for p, value := range mustPattern(t, "/*/swap").Find(doc) {
+			found = append(found, p.String()+"="+value.(string))
+		}
+
+		assert.Equal(t, []string{"/p/swap=value"}, found)
+	})
+}
+
+func TestPattern_SetAll(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should set all matching locations", func(t *testing.T) {
+		t.Parallel()
+
+		doc := testPatternDocument(t)
+		pattern := mustPattern(t, "/paths/*/*/responses/*/description")
+
+		_, err := pattern.SetAll(doc, "redacted")
+		require.NoError(t, err)
+
+		for _, value := range pattern.Find(doc) {
+			assert.Equal(t, "redacted", value)
+		}
+		assert.Len(t, foundPointers(pattern, doc), 4)
+	})
+
+	t.Run("should set descendants before ancestors", func(t *testing.T) {
+		t.Parallel()
+
+		doc := map[string]any{"a": map[string]any{"a": 1}}
+
+		_, err := mustPattern(t, "/**/a").SetAll(doc, "x")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"a": "x"}, doc)
+	})
+
+	t.Run("should return the updated document", func(t *testing.T) {
+		t.Parallel()
+
+		doc := []int{1, 2, 3}
+
+		updated, err := mustPattern(t, "/*").SetAll(doc, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 0, 0}, updated)
+	})
+
+	t.Run("should stop on error", func(t *testing.T) {
+		t.Parallel()
+
+		doc := map[string]any{"a": map[string]int{"b": 1}}
+
+		_, err := mustPattern(t, "/a/*").SetAll(doc, "not an int")
+		require.ErrorIs(t, err, ErrPointer)
+	})
+
+	t.Run("should add a terminal literal token to all matching parents", func(t *testing.T) {
+		t.Parallel()
+
+		doc := map[string]any{
+			"items": []any{[]any{1}, []any{}},
+			"objs":  map[string]any{"a": map[string]any{}, "b": map[string]any{"newKey": 1}},
+		}
+
+		updated, err := mustPattern(t, "/items/*/-").SetAll(doc, 2)
+		require.NoError(t, err)
+		_, err = mustPattern(t, "/objs/*/newKey").SetAll(updated, 3)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]any{
+			"items": []any{[]any{1, 2}, []any{2}},
+			"objs":  map[string]any{"a": map[string]any{"newKey": 3}, "b": map[string]any{"newKey": 3}},
+		}, doc)
+	})
+
+	t.Run("should only set existing locations for a literal token after any depth", func(t *testing.T) {
+		t.Parallel()
+
+		doc := map[string]any{"a": map[string]any{"b": 1}}
+
+		_, err := mustPattern(t, "/**/newKey").SetAll(doc, 2)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"a": map[string]any{"b": 1}}, doc)
+	})
+
+	t.Run("should stop on a parent that cannot hold the token", func(t *testing.T) {
+		t.Parallel()
+
+		doc := map[string]any{"a": map[string]any{}, "b": "scalar"}
+
+		_, err := mustPattern(t, "/*/newKey").SetAll(doc, 1)
+		require.ErrorIs(t, err, ErrPointer)
+	})
+
+	t.Run("without matches", func(t *testing.T) {
+		t.Parallel()
+
+		doc := map[string]any{"a": 1}
+
+		updated, err := mustPattern(t, "/b/*").SetAll(doc, 2)
+		require.NoError(t, err)
+		assert.Equal(t, doc, updated)
+	})
+}
@@ -5,6 +5,7 @@
 package jsonpointer
 
 import (
+	"encoding"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,24 +14,41 @@ import (
 	"strings"
 )
 
+//nolint:gochecknoglobals // it's okay to declare a reflect.Type as a private global
+var (
+	stringType          = reflect.TypeFor[string]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
 const (
 	emptyPointer     = ``
 	pointerSeparator = `/`
+
+	decodedTokensBufferSize = 16
 )
 
 // Pointer is a representation of a json pointer.
 //
-// Use [Pointer.Get] to retrieve a value or [Pointer.Set] to set a value.
+// Use [Pointer.Get] to retrieve a value, [Pointer.Set] to set a value, [Pointer.Insert] to insert a value or
+// [Pointer.Delete] to remove a value.
 //
 // It works with any go type interpreted as a JSON document, which means:
 //
 //   - if a type implements [JSONPointable], its [JSONPointable.JSONLookup] method is used to resolve [Pointer.Get]
 //   - if a type implements [JSONSetable], its [JSONSetable.JSONSet] method is used to resolve [Pointer.Set]
-//   - a go map[K]V is interpreted as an object, with type K assignable to a string
+//   - if a type implements [JSONDeletable], its [JSONDeletable.JSONDelete] method is used to resolve [Pointer.Delete]
+//   - a go map[K]V is interpreted as an object. Like with [encoding/json], type K may be a string
+//     kind, an integer kind or implement [encoding.TextUnmarshaler]
 //   - a go slice []T is interpreted as an array
+//   - a go array [N]T is interpreted as an array of fixed size: its elements may be set when it is
+//     addressable (e.g. reached through a pointer), but it cannot grow nor shrink
 //   - a go struct is interpreted as an object, with exported fields interpreted as keys
 //   - promoted fields from an embedded struct are traversed
-//   - scalars (e.g. int, float64 ...), channels, functions and go arrays cannot be traversed
+//   - scalars (e.g. int, float64 ...), channels and functions cannot be traversed
+//
+// Pointers are immutable: methods that compose pointers, such as [Pointer.Append] or
+// [Pointer.Parent], return new values that share no state with the original. A Pointer may thus be
+// safely shared between goroutines.
 //
 // For struct s resolved by reflection, key mappings honor the conventional struct tag `json`.
 //
@@ -43,6 +61,7 @@ const (
 //   - anonymous fields are not traversed if untagged
 type Pointer struct {
 	referenceTokens []string
+	strict          bool
 }
 
 // New creates a new json pointer from its string representation.
@@ -53,13 +72,32 @@ func New(jsonPointerString string) (Pointer, error) {
 	return p, err
 }
 
+// NewStrict creates a new json pointer from its string representation, like [New], but enforces
+// the RFC 6901 grammar strictly.
+//
+// In strict mode:
+//
+//   - the "~" escape character must be followed by "0" or "1". Invalid escape sequences are reported
+//     with their byte position, in an error wrapping [ErrInvalidEscape]
+//   - tokens used as array indices by [Pointer.Get], [Pointer.Set], [Pointer.Delete] and
+//     [Pointer.Offset] must be either "0" or a decimal number without sign nor leading zeros. Other
+//     tokens, such as "+1", "-0" or "01", are reported by an error wrapping [ErrInvalidIndex]
+//
+// By contrast, [New] leaves invalid escape sequences as is and accepts any integer as array index.
+func NewStrict(jsonPointerString string) (Pointer, error) {
+	p := Pointer{strict: true}
+	err := p.parse(jsonPointerString)
+
+	return p, err
+}
+
 // Get uses the pointer to retrieve a value from a JSON document.
 //
 // It returns the value with its type as a [reflect.Kind] or an error.
-func (p *Pointer) Get(document any, opts ...Option) (any, reflect.Kind, error) {
+func (p Pointer) Get(document any, opts ...Option) (any, reflect.Kind, error) {
 	o := optionsWithDefaults(opts)
 
-	return p.get(document, o.provider)
+	return p.get(document, o)
 }
 
 // Set uses the pointer to set a value from a data type that represent a JSON document.
@@ -72,6 +110,10 @@ func (p *Pointer) Get(document any, opts ...Option) (any, reflect.Kind, error) {
 //
 // Callers that rely on this in-place behavior may continue to ignore the returned document.
 //
+// Struct and array values that are not addressable, e.g. stored by value in a map[string]any or in
+// a field of type any, are copied: the change is applied to the copy, which then replaces the
+// original value in its parent.
+//
 // The returned document is only load-bearing when Set cannot mutate in place.
 //
 // This happens in one specific case: appending to a top-level slice passed by value (e.g. document
@@ -82,31 +124,90 @@ func (p *Pointer) Get(document any, opts ...Option) (any, reflect.Kind, error) {
 // Pass *[]T if you want in-place rebind for that case as well.
 //
 // See [ErrDashToken] for the semantics of the "-" token.
-func (p *Pointer) Set(document any, value any, opts ...Option) (any, error) {
+func (p Pointer) Set(document any, value any, opts ...Option) (any, error) {
+	o := optionsWithDefaults(opts)
+
+	return p.set(document, value, o)
+}
+
+// Insert uses the pointer to add a value to a data type that represent a JSON document, with the
+// semantics of the RFC 6902 "add" operation.
+//
+// Unlike [Pointer.Set], which replaces the element at a numeric index, Insert adds the value before
+// that element and shifts the subsequent elements up. The index may be equal to the length of the
+// slice, in which case the value is appended, like with the "-" token.
+//
+// For all other kinds of targets (e.g. map entries, struct fields), Insert behaves like
+// [Pointer.Set].
+//
+// # Mutation contract
+//
+// Insert follows the same mutation contract as [Pointer.Set]: the document is mutated in place
+// whenever Go's type system allows it.
+//
+// The returned document is only load-bearing when inserting into a top-level slice passed by value
+// (e.g. document of type []T rather than *[]T). The grown slice is returned as a new slice with its
+// own backing array, and the original slice is left untouched.
+func (p Pointer) Insert(document any, value any, opts ...Option) (any, error) {
+	o := optionsWithDefaults(opts)
+
+	return p.insert(document, value, o)
+}
+
+// Delete uses the pointer to remove a value from a data type that represent a JSON document.
+//
+// Depending on the type of the parent of the targeted value:
+//
+//   - if it implements [JSONDeletable], its [JSONDeletable.JSONDelete] method is used
+//   - a map entry is deleted (it must exist)
+//   - a slice element is spliced out, and subsequent elements are shifted down
+//   - a struct field is reset to its zero value
+//
+// The empty pointer, which refers to the whole document, cannot be deleted.
+//
+// # Mutation contract
+//
+// Delete follows the same mutation contract as [Pointer.Set]: the document is mutated in place
+// whenever Go's type system allows it.
+//
+// The returned document is only load-bearing when removing an element from a top-level slice passed
+// by value (e.g. document of type []T rather than *[]T). The shortened slice is returned as a new
+// slice with its own backing array, and the original slice is left untouched.
+//
+// The RFC 6901 "-" token designates a nonexistent element: it cannot be deleted and the returned
+// error wraps [ErrDashToken].
+func (p Pointer) Delete(document any, opts ...Option) (any, error) {
 	o := optionsWithDefaults(opts)
 
-	return p.set(document, value, o.provider)
+	return p.remove(document, o)
 }
 
 // DecodedTokens returns the decoded (unescaped) tokens of this JSON pointer.
-func (p *Pointer) DecodedTokens() []string {
-	result := make([]string, 0, len(p.referenceTokens))
+func (p Pointer) DecodedTokens() []string {
+	return p.appendDecodedTokens(make([]string, 0, len(p.referenceTokens)))
+}
+
+// appendDecodedTokens appends the decoded tokens of p to buf.
+//
+// Internal callers pass a buffer allocated on the stack, so that walking a document does not
+// allocate for pointers with up to [decodedTokensBufferSize] tokens.
+func (p *Pointer) appendDecodedTokens(buf []string) []string {
 	for _, token := range p.referenceTokens {
-		result = append(result, Unescape(token))
+		buf = append(buf, Unescape(token))
 	}
 
-	return result
+	return buf
 }
 
 // IsEmpty returns true if this is an empty json pointer.
 //
 // This indicates that it points to the root document.
-func (p *Pointer) IsEmpty() bool {
+func (p Pointer) IsEmpty() bool {
 	return len(p.referenceTokens) == 0
 }
 
 // String representation of a pointer.
-func (p *Pointer) String() string {
+func (p Pointer) String() string {
 	if len(p.referenceTokens) == 0 {
 		return emptyPointer
 	}
@@ -114,6 +215,32 @@ func (p *Pointer) String() string {
 	return pointerSeparator + strings.Join(p.referenceTokens, pointerSeparator)
 }
 
+// MarshalText implements [encoding.TextMarshaler].
+//
+// A pointer is marshaled as its string representation, e.g. as a JSON string by
+// [encoding/json.Marshal].
+func (p Pointer) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler].
+//
+// The text is parsed like by [New]. An invalid pointer is reported by an error wrapping
+// [ErrInvalidStart], and leaves p unchanged.
+//
+// This allows a [Pointer] to be used directly as the type of a field decoded from JSON, or as a
+// command line flag with [flag.TextVar].
+func (p *Pointer) UnmarshalText(text []byte) error {
+	parsed := Pointer{strict: p.strict}
+	if err := parsed.parse(string(text)); err != nil {
+		return err
+	}
+
+	*p = parsed
+
+	return nil
+}
+
 // Offset returns the byte offset, in the raw JSON text of document, of the location referenced by
 // this pointer's terminal token.
 //
@@ -147,20 +274,21 @@ func (p *Pointer) String() string {
 //   - document is not syntactically valid JSON;
 //   - the structure of document does not match the pointer (e.g. traversing
 //     into a scalar, or a token that is neither a valid key nor a valid
-//     numeric index);
-//   - a referenced key or index does not exist in document;
+//     numeric index). Traversing into a scalar wraps [ErrTypeMismatch];
+//   - a referenced key or index does not exist in document. The returned
+//     error wraps [ErrNotFound] or [ErrIndexOutOfRange];
 //   - the pointer's terminal token is the RFC 6901 "-" array token, which
 //     designates a nonexistent element and therefore has no offset in the
 //     source. The returned error wraps [ErrDashToken].
 //
-// All errors wrap [ErrPointer].
-func (p *Pointer) Offset(document string) (int64, error) {
+// All errors are [*PointerError] values, which wrap [ErrPointer].
+func (p Pointer) Offset(document string) (int64, error) {
 	dec := json.NewDecoder(strings.NewReader(document))
 	var offset int64
-	for _, ttk := range p.DecodedTokens() {
+	for i, ttk := range p.DecodedTokens() {
 		tk, err := dec.Token()
 		if err != nil {
-			return 0, err
+			return 0, p.errAt(opOffset, i, reflect.Invalid, err)
 		}
 		switch tk := tk.(type) {
 		case json.Delim:
@@ -168,18 +296,18 @@ func (p *Pointer) Offset(document string) (int64, error) {
 			case '{':
 				offset, err = offsetSingleObject(dec, ttk)
 				if err != nil {
-					return 0, err
+					return 0, p.errAt(opOffset, i, reflect.Map, err)
 				}
 			case '[':
-				offset, err = offsetSingleArray(dec, ttk)
+				offset, err = offsetSingleArray(dec, ttk, p.strict)
 				if err != nil {
-					return 0, err
+					return 0, p.errAt(opOffset, i, reflect.Slice, err)
 				}
 			default:
-				return 0, fmt.Errorf("invalid token %#v: %w", tk, ErrPointer)
+				return 0, p.errAt(opOffset, i, reflect.Invalid, fmt.Errorf("invalid token %#v: %w", tk, ErrPointer))
 			}
 		default:
-			return 0, fmt.Errorf("invalid token %#v: %w", tk, ErrPointer)
+			return 0, p.errAt(opOffset, i, nodeKind(tk), fmt.Errorf("invalid token %#v: %w: %w", tk, ErrTypeMismatch, ErrPointer))
 		}
 	}
 	return skipJSONSeparator(document, offset), nil
@@ -223,15 +351,25 @@ func (p *Pointer) parse(jsonPointerString string) error {
 		return errors.Join(ErrInvalidStart, ErrPointer)
 	}
 
+	if p.strict {
+		if pos := invalidEscapePosition(jsonPointerString); pos >= 0 {
+			return errInvalidEscape(jsonPointerString, pos)
+		}
+	}
+
 	referenceTokens := strings.Split(jsonPointerString, pointerSeparator)
 	p.referenceTokens = append(p.referenceTokens, referenceTokens[1:]...)
 
 	return nil
 }
 
-func (p *Pointer) get(node any, nameProvider NameProvider) (any, reflect.Kind, error) {
-	if nameProvider == nil {
-		nameProvider = defaultOptions.provider
+func (p *Pointer) get(node any, o options) (any, reflect.Kind, error) {
+	if p.strict {
+		o.strict = true
+	}
+
+	if o.provider == nil {
+		o.provider = defaultOptions.provider
 	}
 
 	kind := reflect.Invalid
@@ -241,12 +379,12 @@ func (p *Pointer) get(node any, nameProvider NameProvider) (any, reflect.Kind, e
 		return node, kind, nil
 	}
 
-	for _, token := range p.referenceTokens {
+	for i, token := range p.referenceTokens {
 		decodedToken := Unescape(token)
 
-		r, knd, err := getSingleImpl(node, decodedToken, nameProvider)
+		r, knd, err := getSingleImpl(node, decodedToken, o)
 		if err != nil {
-			return nil, knd, err
+			return nil, knd, p.errAt(opGet, i, nodeKind(node), err)
 		}
 		node = r
 	}
@@ -257,15 +395,13 @@ func (p *Pointer) get(node any, nameProvider NameProvider) (any, reflect.Kind, e
 	return node, kind, nil
 }
 
-func (p *Pointer) set(node, data any, nameProvider NameProvider) (any, error) {
-	knd := reflect.ValueOf(node).Kind()
+func (p *Pointer) set(node, data any, o options) (any, error) {
+	if p.strict {
+		o.strict = true
+	}
 
-	if knd != reflect.Pointer && knd != reflect.Struct && knd != reflect.Map && knd != reflect.Slice && knd != reflect.Array {
-		return node, errors.Join(
-			fmt.Errorf("unexpected type: %T", node), //nolint:err113 // err wrapping is carried out by errors.Join, not fmt.Errorf.
-			ErrUnsupportedValueType,
-			ErrPointer,
-		)
+	if err := checkMutable(node); err != nil {
+		return node, p.errOn(opSet, nodeKind(node), err)
 	}
 
 	// full document when empty
@@ -273,38 +409,133 @@ func (p *Pointer) set(node, data any, nameProvider NameProvider) (any, error) {
 		return node, nil
 	}
 
-	if nameProvider == nil {
-		nameProvider = defaultOptions.provider
+	if o.provider == nil {
+		o.provider = defaultOptions.provider
+	}
+
+	var buf [decodedTokensBufferSize]string
+
+	return p.setAt(node, p.appendDecodedTokens(buf[:0]), data, o)
+}
+
+// checkMutable verifies that a document is of a kind that may be mutated by a pointer.
+func checkMutable(node any) error {
+	knd := reflect.ValueOf(node).Kind()
+
+	if knd != reflect.Pointer && knd != reflect.Struct && knd != reflect.Map && knd != reflect.Slice && knd != reflect.Array {
+		return errors.Join(
+			fmt.Errorf("unexpected type: %T", node), //nolint:err113 // err wrapping is carried out by errors.Join, not fmt.Errorf.
+			ErrUnsupportedValueType,
+			ErrNotSettable,
+			ErrPointer,
+		)
 	}
 
-	return p.setAt(node, p.referenceTokens, data, nameProvider)
+	return nil
 }
 
-// setAt recursively walks the token list, setting the data at the terminal token and rebinding any
+// setAt recursively walks the list of decoded tokens, setting the data at the terminal token and rebinding any
 // new child reference (e.g. a slice header returned by an "-" append) into its parent on the way
 // back up.
 //
 // Returning the (possibly new) node at each level is what makes append work at any depth without
 // requiring the caller to pass a pointer to the containing slice: the new slice header propagates
 // up and each parent rebinds it via the appropriate kind-specific setter.
-func (p *Pointer) setAt(node any, tokens []string, data any, nameProvider NameProvider) (any, error) {
-	decodedToken := Unescape(tokens[0])
+func (p *Pointer) setAt(node any, tokens []string, data any, o options) (any, error) {
+	return p.mutateAt(node, tokens, opSet, o, func(parent any, decodedToken string) (any, error) {
+		return setSingleImpl(parent, data, decodedToken, o)
+	})
+}
+
+// mutateAt walks the token list like [Pointer.setAt], but delegates the change at the terminal token
+// to the mutate function.
+//
+// mutate receives the parent node and the terminal decoded token, and returns the (possibly new)
+// parent node, which is rebound into its own parent on the way back up.
+//
+// Errors are reported as a [PointerError] for the operation op.
+func (p *Pointer) mutateAt(node any, tokens []string, op string, o options, mutate func(any, string) (any, error)) (any, error) {
+	decodedToken := tokens[0]
+	index := len(p.referenceTokens) - len(tokens)
 
 	if len(tokens) == 1 {
-		return setSingleImpl(node, data, decodedToken, nameProvider)
+		updated, err := mutate(node, decodedToken)
+		if err != nil {
+			return updated, p.errAt(op, index, nodeKind(node), err)
+		}
+
+		return updated, nil
 	}
 
-	child, err := p.resolveNodeForToken(node, decodedToken, nameProvider)
+	child, err := p.resolveNodeForToken(node, decodedToken, o)
+	if o.createMissing && (err != nil || isNil(child)) {
+		// the new container is attached to node by rebindChild, once the mutation has succeeded
+		if created, ok := createMissingChild(node, decodedToken, tokens[1], o); ok {
+			child, err = created, nil
+		}
+	}
 	if err != nil {
-		return node, err
+		return node, p.errAt(op, index, nodeKind(node), err)
+	}
+
+	var copied bool
+	if canRebind(node) {
+		child, copied = copyOnWrite(child)
 	}
 
-	newChild, err := p.setAt(child, tokens[1:], data, nameProvider)
+	newChild, err := p.mutateAt(child, tokens[1:], op, o, mutate)
 	if err != nil {
 		return node, err
 	}
 
-	return rebindChild(node, decodedToken, newChild, nameProvider)
+	if copied {
+		// rebind the modified copy with its original type, not as a pointer
+		newChild = reflect.ValueOf(newChild).Elem().Interface()
+	}
+
+	updated, err := rebindChild(node, decodedToken, newChild, o)
+	if err != nil {
+		return updated, p.errAt(op, index, nodeKind(node), err)
+	}
+
+	return updated, nil
+}
+
+// copyOnWrite returns an addressable copy of a child struct or array value.
+//
+// Such values are not addressable when held by an interface (e.g. in a map[string]any or in a field
+// of type any) or by a map, and cannot be mutated in place. The mutation is applied to the copy,
+// which is then rebound into the parent by [rebindChild].
+//
+// Other values are returned as is.
+func copyOnWrite(child any) (any, bool) {
+	v := reflect.ValueOf(child)
+	if v.Kind() != reflect.Struct && v.Kind() != reflect.Array {
+		return child, false
+	}
+
+	cp := reflect.New(v.Type())
+	cp.Elem().Set(v)
+
+	return cp.Interface(), true
+}
+
+// canRebind tells if a modified copy of a child may be rebound into node by [rebindChild].
+//
+// This is not the case for a struct or an array that is not addressable (e.g. a root document passed
+// by value): the child is then left as is, and the mutation reports that it cannot be set.
+func canRebind(node any) bool {
+	if _, ok := node.(JSONPointable); ok {
+		return false
+	}
+
+	v := reflect.Indirect(reflect.ValueOf(node))
+	switch v.Kind() {
+	case reflect.Struct, reflect.Array:
+		return v.CanAddr()
+	default:
+		return true
+	}
 }
 
 // rebindChild writes newChild back into node at decodedToken.
@@ -317,20 +548,37 @@ func (p *Pointer) setAt(node any, tokens []string, data any, nameProvider NamePr
 //
 // Parents implementing [JSONPointable] are left alone: they took ownership of the child via
 // JSONLookup and did not opt into a JSONSet-based rebind on intermediate tokens.
-func rebindChild(node any, decodedToken string, newChild any, nameProvider NameProvider) (any, error) {
-	if _, ok := node.(JSONPointable); ok {
+func rebindChild(node any, decodedToken string, newChild any, o options) (any, error) {
+	switch typed := node.(type) {
+	case JSONPointable:
+		return node, nil
+	case map[string]any:
+		typed[decodedToken] = newChild
 		return node, nil
+	case *map[string]any:
+		(*typed)[decodedToken] = newChild
+		return node, nil
+	case []any:
+		if o.createMissing && isAppendToken(decodedToken, len(typed), o) {
+			return append(typed, newChild), nil
+		}
+		return node, rebindJSONArray(typed, decodedToken, newChild, o)
+	case *[]any:
+		if o.createMissing && isAppendToken(decodedToken, len(*typed), o) {
+			*typed = append(*typed, newChild)
+			return node, nil
+		}
+		return node, rebindJSONArray(*typed, decodedToken, newChild, o)
 	}
 
 	rValue := reflect.Indirect(reflect.ValueOf(node))
 
 	switch rValue.Kind() {
 	case reflect.Struct:
-		nm, ok := nameProvider.GetGoNameForType(rValue.Type(), decodedToken)
-		if !ok {
-			return node, fmt.Errorf("object has no field %q: %w", decodedToken, ErrPointer)
+		fld, _, err := structField(rValue, decodedToken, o)
+		if err != nil {
+			return node, err
 		}
-		fld := rValue.FieldByName(nm)
 		if !fld.CanSet() {
 			return node, nil
 		}
@@ -338,27 +586,192 @@ func rebindChild(node any, decodedToken string, newChild any, nameProvider NameP
 		return node, nil
 
 	case reflect.Map:
-		rValue.SetMapIndex(reflect.ValueOf(decodedToken), reflect.ValueOf(newChild))
+		return node, rebindInMap(rValue, decodedToken, newChild)
+
+	case reflect.Slice, reflect.Array:
+		return rebindInArray(node, rValue, decodedToken, newChild, o)
+
+	default:
+		return node, errInvalidReference(decodedToken)
+	}
+}
+
+// rebindInMap writes newChild back into a map entry, after converting the token into a map key.
+func rebindInMap(rValue reflect.Value, decodedToken string, newChild any) error {
+	kv, err := mapKey(rValue.Type(), decodedToken)
+	if err != nil {
+		return err
+	}
+
+	rValue.SetMapIndex(kv, rebindValue(newChild, rValue.Type().Elem()))
+
+	return nil
+}
+
+// rebindInArray writes newChild back into an element of a slice or a go array.
+//
+// With [WithCreateMissing], a new element is appended to the slice instead.
+func rebindInArray(node any, rValue reflect.Value, decodedToken string, newChild any, o options) (any, error) {
+	if rValue.Kind() == reflect.Slice && o.createMissing && isAppendToken(decodedToken, rValue.Len(), o) {
+		// a new element was created by createMissingChild
+		newSlice := reflect.Append(rValue, rebindValue(newChild, rValue.Type().Elem()))
+		if rValue.CanSet() {
+			rValue.Set(newSlice)
+			return node, nil
+		}
+		return newSlice.Interface(), nil
+	}
+	if decodedToken == dashToken {
+		return node, errDashIntermediate()
+	}
+	idx, err := parseIndex(decodedToken, o.strict)
+	if err != nil {
+		return node, err
+	}
+	elem := rValue.Index(idx)
+	if !elem.CanSet() {
 		return node, nil
+	}
+	assignReflectValue(elem, newChild)
+	return node, nil
+}
 
-	case reflect.Slice:
-		if decodedToken == dashToken {
-			return node, errDashIntermediate()
+// createMissingChild creates a new empty container for a missing (or nil) child of node at
+// decodedToken.
+//
+// The type of the container is the one expected by node for this child. When node accepts any
+// value (e.g. a map[string]any), the container is a []any if nextToken designates an array element
+// ("0" or "-"), and a map[string]any otherwise.
+//
+// Struct values are created as a pointer to a new struct, so they may be mutated before being
+// rebound.
+func createMissingChild(node any, decodedToken, nextToken string, o options) (any, bool) {
+	if _, ok := node.(JSONPointable); ok {
+		return nil, false
+	}
+
+	rValue := reflect.Indirect(reflect.ValueOf(node))
+
+	switch rValue.Kind() {
+	case reflect.Map:
+		if rValue.IsNil() {
+			return nil, false
 		}
-		idx, err := strconv.Atoi(decodedToken)
-		if err != nil {
-			return node, errors.Join(err, ErrPointer)
+
+		if _, err := mapKey(rValue.Type(), decodedToken); err != nil {
+			return nil, false
 		}
-		elem := rValue.Index(idx)
-		if !elem.CanSet() {
-			return node, nil
+
+		return newContainer(rValue.Type().Elem(), nextToken)
+
+	case reflect.Struct:
+		fld, _, err := structField(rValue, decodedToken, o)
+		if err != nil || !fld.CanSet() {
+			return nil, false
 		}
-		assignReflectValue(elem, newChild)
-		return node, nil
+
+		return newContainer(fld.Type(), nextToken)
+
+	case reflect.Slice, reflect.Array:
+		if rValue.Kind() == reflect.Slice && isAppendToken(decodedToken, rValue.Len(), o) {
+			// the new element is appended by rebindChild
+			return newContainer(rValue.Type().Elem(), nextToken)
+		}
+
+		idx, err := parseIndex(decodedToken, o.strict)
+		if err != nil || idx < 0 || idx >= rValue.Len() || !rValue.Index(idx).CanSet() {
+			return nil, false
+		}
+
+		return newContainer(rValue.Type().Elem(), nextToken)
 
 	default:
-		return node, errInvalidReference(decodedToken)
+		return nil, false
+	}
+}
+
+func newContainer(tpe reflect.Type, nextToken string) (any, bool) {
+	switch tpe.Kind() {
+	case reflect.Interface:
+		var container any = map[string]any{}
+		if nextToken == dashToken || nextToken == "0" {
+			container = []any{}
+		}
+
+		if !reflect.TypeOf(container).AssignableTo(tpe) {
+			return nil, false
+		}
+
+		return container, true
+
+	case reflect.Map:
+		return reflect.MakeMap(tpe).Interface(), true
+
+	case reflect.Slice:
+		return reflect.MakeSlice(tpe, 0, 0).Interface(), true
+
+	case reflect.Pointer:
+		return reflect.New(tpe.Elem()).Interface(), true
+
+	case reflect.Struct:
+		return reflect.New(tpe).Interface(), true
+
+	default:
+		return nil, false
+	}
+}
+
+// checkSetMode verifies that setting a value at decodedToken is allowed by the options
+// [WithReplaceOnly] and [WithAddOnly], given that a value already exists there or not.
+func checkSetMode(exists bool, decodedToken string, o options) error {
+	switch o.setMode {
+	case setModeReplaceOnly:
+		if !exists {
+			return errNothingToReplace(decodedToken)
+		}
+	case setModeAddOnly:
+		if exists {
+			return errAlreadyExists(decodedToken)
+		}
+	case setModeAny:
+	}
+
+	return nil
+}
+
+// isAppendToken tells if a token designates the position after the last element of a slice, that
+// is the "-" token, or the length of the slice when missing values are created (see
+// [WithCreateMissing]).
+func isAppendToken(decodedToken string, length int, o options) bool {
+	if decodedToken == dashToken {
+		return true
+	}
+
+	if !o.createMissing {
+		return false
+	}
+
+	idx, err := parseIndex(decodedToken, o.strict)
+
+	return err == nil && idx == length
+}
+
+// rebindValue returns newChild as a value of type tpe, unwrapping a pointer when tpe is the pointee
+// type.
+//
+// Like [assignReflectValue], this tolerates the pointer-wrapping performed by [typeFromValue], as
+// well as the one of struct values created by [createMissingChild].
+func rebindValue(newChild any, tpe reflect.Type) reflect.Value {
+	nv := reflect.ValueOf(newChild)
+	if !nv.IsValid() {
+		return reflect.Zero(tpe)
+	}
+
+	if !nv.Type().AssignableTo(tpe) && nv.Kind() == reflect.Pointer && nv.Elem().Type().AssignableTo(tpe) {
+		return nv.Elem()
 	}
+
+	return nv
 }
 
 // assignReflectValue assigns src into dst, unwrapping a pointer when dst expects the pointee type.
@@ -378,25 +791,23 @@ func assignReflectValue(dst reflect.Value, src any) {
 	}
 }
 
-func (p *Pointer) resolveNodeForToken(node any, decodedToken string, nameProvider NameProvider) (next any, err error) {
+func (p *Pointer) resolveNodeForToken(node any, decodedToken string, o options) (next any, err error) {
 	// check for nil during traversal
 	if isNil(node) {
-		return nil, fmt.Errorf("cannot traverse through nil value at %q: %w", decodedToken, ErrPointer)
+		return nil, fmt.Errorf("cannot traverse through nil value at %q: %w: %w", decodedToken, ErrNilTraversal, ErrPointer)
 	}
 
-	pointable, ok := node.(JSONPointable)
-	if ok {
-		r, err := pointable.JSONLookup(decodedToken)
-		if err != nil {
-			return nil, err
-		}
-
-		fld := reflect.ValueOf(r)
-		if fld.CanAddr() && fld.Kind() != reflect.Interface && fld.Kind() != reflect.Map && fld.Kind() != reflect.Slice && fld.Kind() != reflect.Pointer {
-			return fld.Addr().Interface(), nil
-		}
-
-		return r, nil
+	switch typed := node.(type) {
+	case JSONPointable:
+		return resolveInPointable(typed, decodedToken)
+	case map[string]any:
+		return resolveInJSONObject(typed, decodedToken)
+	case *map[string]any:
+		return resolveInJSONObject(*typed, decodedToken)
+	case []any:
+		return resolveInJSONArray(typed, decodedToken, o)
+	case *[]any:
+		return resolveInJSONArray(*typed, decodedToken, o)
 	}
 
 	rValue := reflect.Indirect(reflect.ValueOf(node))
@@ -404,30 +815,33 @@ func (p *Pointer) resolveNodeForToken(node any, decodedToken string, nameProvide
 
 	switch kind {
 	case reflect.Struct:
-		nm, ok := nameProvider.GetGoNameForType(rValue.Type(), decodedToken)
-		if !ok {
-			return nil, fmt.Errorf("object has no field %q: %w", decodedToken, ErrPointer)
+		fld, _, err := structField(rValue, decodedToken, o)
+		if err != nil {
+			return nil, err
 		}
 
-		return typeFromValue(rValue.FieldByName(nm)), nil
+		return typeFromValue(fld), nil
 
 	case reflect.Map:
-		kv := reflect.ValueOf(decodedToken)
-		mv := rValue.MapIndex(kv)
+		kv, err := mapKey(rValue.Type(), decodedToken)
+		if err != nil {
+			return nil, err
+		}
 
+		mv := rValue.MapIndex(kv)
 		if !mv.IsValid() {
 			return nil, errNoKey(decodedToken)
 		}
 
 		return typeFromValue(mv), nil
 
-	case reflect.Slice:
+	case reflect.Slice, reflect.Array:
 		if decodedToken == dashToken {
 			return nil, errDashIntermediate()
 		}
-		tokenIndex, err := strconv.Atoi(decodedToken)
+		tokenIndex, err := parseIndex(decodedToken, o.strict)
 		if err != nil {
-			return nil, errors.Join(err, ErrPointer)
+			return nil, err
 		}
 
 		sLength := rValue.Len()
@@ -442,34 +856,275 @@ func (p *Pointer) resolveNodeForToken(node any, decodedToken string, nameProvide
 	}
 }
 
-func isNil(input any) bool {
-	if input == nil {
-		return true
+// resolveInPointable looks up the child of a [JSONPointable] for an intermediate token.
+func resolveInPointable(pointable JSONPointable, decodedToken string) (any, error) {
+	r, err := pointable.JSONLookup(decodedToken)
+	if err != nil {
+		return nil, err
 	}
 
-	kind := reflect.TypeOf(input).Kind()
-	switch kind {
-	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan:
-		return reflect.ValueOf(input).IsNil()
-	default:
-		return false
+	fld := reflect.ValueOf(r)
+	if fld.CanAddr() && fld.Kind() != reflect.Interface && fld.Kind() != reflect.Map && fld.Kind() != reflect.Slice && fld.Kind() != reflect.Pointer {
+		return fld.Addr().Interface(), nil
 	}
+
+	return r, nil
 }
 
-func typeFromValue(v reflect.Value) any {
-	if v.CanAddr() && v.Kind() != reflect.Interface && v.Kind() != reflect.Map && v.Kind() != reflect.Slice && v.Kind() != reflect.Pointer {
-		return v.Addr().Interface()
+// Fast path helpers for documents decoded from JSON into an any, i.e. trees made of map[string]any
+// and []any. They resolve tokens without reflection, and do not allocate.
+
+func getFromJSONObject(node map[string]any, decodedToken string) (any, reflect.Kind, error) {
+	v, ok := node[decodedToken]
+	if !ok {
+		return nil, reflect.Map, errNoKey(decodedToken)
 	}
 
-	return v.Interface()
+	return v, reflect.Map, nil
 }
 
-// GetForToken gets a value for a json pointer token 1 level deep.
-func GetForToken(document any, decodedToken string, opts ...Option) (any, reflect.Kind, error) {
-	o := optionsWithDefaults(opts)
+func getFromJSONArray(node []any, decodedToken string, o options) (any, reflect.Kind, error) {
+	if decodedToken == dashToken {
+		return nil, reflect.Slice, errDashOnGet()
+	}
 
-	return getSingleImpl(document, decodedToken, o.provider)
-}
+	idx, err := jsonArrayIndex(node, decodedToken, o)
+	if err != nil {
+		return nil, reflect.Slice, err
+	}
+
+	return node[idx], reflect.Slice, nil
+}
+
+func resolveInJSONObject(node map[string]any, decodedToken string) (any, error) {
+	v, ok := node[decodedToken]
+	if !ok {
+		return nil, errNoKey(decodedToken)
+	}
+
+	return v, nil
+}
+
+func resolveInJSONArray(node []any, decodedToken string, o options) (any, error) {
+	if decodedToken == dashToken {
+		return nil, errDashIntermediate()
+	}
+
+	idx, err := jsonArrayIndex(node, decodedToken, o)
+	if err != nil {
+		return nil, err
+	}
+
+	return node[idx], nil
+}
+
+// setInJSONObject sets a member of a JSON object, allocating the map through the pointer if it is nil.
+func setInJSONObject(node *map[string]any, data any, decodedToken string, o options) error {
+	_, exists := (*node)[decodedToken]
+	if err := checkSetMode(exists, decodedToken, o); err != nil {
+		return err
+	}
+
+	if *node == nil {
+		*node = make(map[string]any)
+	}
+
+	(*node)[decodedToken] = data
+
+	return nil
+}
+
+func setInJSONArray(node []any, data any, decodedToken string, o options) error {
+	idx, err := jsonArrayIndex(node, decodedToken, o)
+	if err != nil {
+		return err
+	}
+
+	if err := checkSetMode(true, decodedToken, o); err != nil {
+		return err
+	}
+
+	node[idx] = data
+
+	return nil
+}
+
+func rebindJSONArray(node []any, decodedToken string, newChild any, o options) error {
+	if decodedToken == dashToken {
+		return errDashIntermediate()
+	}
+
+	idx, err := jsonArrayIndex(node, decodedToken, o)
+	if err != nil {
+		return err
+	}
+
+	node[idx] = newChild
+
+	return nil
+}
+
+// jsonArrayIndex parses a token used as an index in node, and checks its bounds.
+func jsonArrayIndex(node []any, decodedToken string, o options) (int, error) {
+	idx, err := parseIndex(decodedToken, o.strict)
+	if err != nil {
+		return 0, err
+	}
+
+	if idx < 0 || idx >= len(node) {
+		return 0, errOutOfBounds(len(node), idx)
+	}
+
+	return idx, nil
+}
+
+// mapKey converts a decoded token into a key of the map type mapType, following the rules used by
+// [encoding/json] to decode object keys:
+//
+//   - a key type implementing [encoding.TextUnmarshaler] is decoded by its UnmarshalText method
+//   - a key type of a string kind (including named string types) is converted
+//   - a key type of an integer kind is parsed from the decimal representation of the integer
+//
+// Other key types, or tokens that fail to convert, are reported by an error wrapping
+// [ErrTypeMismatch].
+func mapKey(mapType reflect.Type, decodedToken string) (reflect.Value, error) {
+	kt := mapType.Key()
+
+	if kt == stringType {
+		// fast path for the most common case
+		return reflect.ValueOf(decodedToken), nil
+	}
+
+	if reflect.PointerTo(kt).Implements(textUnmarshalerType) {
+		kv := reflect.New(kt)
+		if err := kv.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(decodedToken)); err != nil { //nolint:forcetypeassert // checked by Implements
+			return reflect.Value{}, errors.Join(err, errMapKey(decodedToken, kt))
+		}
+
+		return kv.Elem(), nil
+	}
+
+	switch {
+	case kt.Kind() == reflect.String:
+		return reflect.ValueOf(decodedToken).Convert(kt), nil
+
+	case isIntKind(kt.Kind()):
+		n, err := strconv.ParseInt(decodedToken, 10, 64)
+		if err != nil || reflect.Zero(kt).OverflowInt(n) {
+			return reflect.Value{}, errMapKey(decodedToken, kt)
+		}
+
+		return reflect.ValueOf(n).Convert(kt), nil
+
+	case isUintKind(kt.Kind()):
+		n, err := strconv.ParseUint(decodedToken, 10, 64)
+		if err != nil || reflect.Zero(kt).OverflowUint(n) {
+			return reflect.Value{}, errMapKey(decodedToken, kt)
+		}
+
+		return reflect.ValueOf(n).Convert(kt), nil
+
+	default:
+		return reflect.Value{}, errMapKey(decodedToken, kt)
+	}
+}
+
+// structField resolves the field of the struct rValue that corresponds to the JSON name
+// decodedToken, and returns it along with its go name.
+//
+// The index path of the field is memoized when options carry a field cache (see [Compiled]).
+func structField(rValue reflect.Value, decodedToken string, o options) (reflect.Value, string, error) {
+	plan, ok := o.fields.lookup(rValue.Type(), decodedToken, o.provider)
+	if !ok {
+		return reflect.Value{}, "", errNoField(decodedToken)
+	}
+
+	fld, err := rValue.FieldByIndexErr(plan.index)
+	if err != nil {
+		// e.g. promoted field of a nil embedded pointer
+		return reflect.Value{}, plan.name, fmt.Errorf("cannot resolve field %s: %w: %w: %w", plan.name, err, ErrNilTraversal, ErrPointer)
+	}
+
+	return fld, plan.name, nil
+}
+
+// parseIndex parses a token used as an array index.
+//
+// In strict mode, the token must comply with the RFC 6901 array-index grammar.
+func parseIndex(decodedToken string, strict bool) (int, error) {
+	if strict && !isArrayIndex(decodedToken) {
+		return 0, errInvalidIndex(decodedToken)
+	}
+
+	idx, err := strconv.Atoi(decodedToken)
+	if err != nil {
+		return 0, errNotANumber(decodedToken, err)
+	}
+
+	return idx, nil
+}
+
+// isArrayIndex tells if a token complies with the RFC 6901 array-index grammar:
+//
+//	array-index = %x30 / ( %x31-39 *(%x30-39) )
+func isArrayIndex(token string) bool {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return false
+	}
+
+	for i := range len(token) {
+		if token[i] < '0' || token[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// invalidEscapePosition returns the byte position of the first "~" that is not followed by "0" or
+// "1", or -1 if all escape sequences are valid.
+func invalidEscapePosition(jsonPointerString string) int {
+	for i := range len(jsonPointerString) {
+		if jsonPointerString[i] != '~' {
+			continue
+		}
+
+		if i+1 == len(jsonPointerString) || (jsonPointerString[i+1] != '0' && jsonPointerString[i+1] != '1') {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func isNil(input any) bool {
+	if input == nil {
+		return true
+	}
+
+	kind := reflect.TypeOf(input).Kind()
+	switch kind {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan:
+		return reflect.ValueOf(input).IsNil()
+	default:
+		return false
+	}
+}
+
+func typeFromValue(v reflect.Value) any {
+	if v.CanAddr() && v.Kind() != reflect.Interface && v.Kind() != reflect.Map && v.Kind() != reflect.Slice && v.Kind() != reflect.Pointer {
+		return v.Addr().Interface()
+	}
+
+	return v.Interface()
+}
+
+// GetForToken gets a value for a json pointer token 1 level deep.
+func GetForToken(document any, decodedToken string, opts ...Option) (any, reflect.Kind, error) {
+	o := optionsWithDefaults(opts)
+
+	return getSingleImpl(document, decodedToken, o)
+}
 
 // SetForToken sets a value for a json pointer token 1 level deep.
 //
@@ -478,14 +1133,32 @@ func GetForToken(document any, decodedToken string, opts ...Option) (any, reflec
 func SetForToken(document any, decodedToken string, value any, opts ...Option) (any, error) {
 	o := optionsWithDefaults(opts)
 
-	return setSingleImpl(document, value, decodedToken, o.provider)
+	return setSingleImpl(document, value, decodedToken, o)
+}
+
+// InsertForToken inserts a value for a json pointer token 1 level deep.
+//
+// See [Pointer.Insert] for the insertion semantics.
+func InsertForToken(document any, decodedToken string, value any, opts ...Option) (any, error) {
+	o := optionsWithDefaults(opts)
+
+	return insertSingleImpl(document, value, decodedToken, o)
+}
+
+// RemoveForToken removes a value for a json pointer token 1 level deep.
+//
+// See [Pointer.Delete] for the mutation contract.
+func RemoveForToken(document any, decodedToken string, opts ...Option) (any, error) {
+	o := optionsWithDefaults(opts)
+
+	return removeSingleImpl(document, decodedToken, o)
 }
 
-func getSingleImpl(node any, decodedToken string, nameProvider NameProvider) (any, reflect.Kind, error) {
+func getSingleImpl(node any, decodedToken string, o options) (any, reflect.Kind, error) {
 	rValue := reflect.Indirect(reflect.ValueOf(node))
 	kind := rValue.Kind()
 	if isNil(node) {
-		return nil, kind, fmt.Errorf("nil value has no field %q: %w", decodedToken, ErrPointer)
+		return nil, kind, fmt.Errorf("nil value has no field %q: %w: %w", decodedToken, ErrNilTraversal, ErrPointer)
 	}
 
 	switch typed := node.(type) {
@@ -496,37 +1169,46 @@ func getSingleImpl(node any, decodedToken string, nameProvider NameProvider) (an
 		}
 		return r, kind, nil
 	case *any: // case of a pointer to interface, that is not resolved by reflect.Indirect
-		return getSingleImpl(*typed, decodedToken, nameProvider)
+		return getSingleImpl(*typed, decodedToken, o)
+	case map[string]any:
+		return getFromJSONObject(typed, decodedToken)
+	case *map[string]any:
+		return getFromJSONObject(*typed, decodedToken)
+	case []any:
+		return getFromJSONArray(typed, decodedToken, o)
+	case *[]any:
+		return getFromJSONArray(*typed, decodedToken, o)
 	}
 
 	switch kind {
 	case reflect.Struct:
-		nm, ok := nameProvider.GetGoNameForType(rValue.Type(), decodedToken)
-		if !ok {
-			return nil, kind, fmt.Errorf("object has no field %q: %w", decodedToken, ErrPointer)
+		fld, _, err := structField(rValue, decodedToken, o)
+		if err != nil {
+			return nil, kind, err
 		}
 
-		fld := rValue.FieldByName(nm)
-
 		return fld.Interface(), kind, nil
 
 	case reflect.Map:
-		kv := reflect.ValueOf(decodedToken)
-		mv := rValue.MapIndex(kv)
+		kv, err := mapKey(rValue.Type(), decodedToken)
+		if err != nil {
+			return nil, kind, err
+		}
 
+		mv := rValue.MapIndex(kv)
 		if mv.IsValid() {
 			return mv.Interface(), kind, nil
 		}
 
 		return nil, kind, errNoKey(decodedToken)
 
-	case reflect.Slice:
+	case reflect.Slice, reflect.Array:
 		if decodedToken == dashToken {
 			return nil, kind, errDashOnGet()
 		}
-		tokenIndex, err := strconv.Atoi(decodedToken)
+		tokenIndex, err := parseIndex(decodedToken, o.strict)
 		if err != nil {
-			return nil, kind, errors.Join(err, ErrPointer)
+			return nil, kind, err
 		}
 		sLength := rValue.Len()
 		if tokenIndex < 0 || tokenIndex >= sLength {
@@ -541,70 +1223,281 @@ func getSingleImpl(node any, decodedToken string, nameProvider NameProvider) (an
 	}
 }
 
-func setSingleImpl(node, data any, decodedToken string, nameProvider NameProvider) (any, error) {
+func setSingleImpl(node, data any, decodedToken string, o options) (any, error) {
 	// check for nil to prevent panic when calling rValue.Type()
 	if isNil(node) {
-		return node, fmt.Errorf("cannot set field %q on nil value: %w", decodedToken, ErrPointer)
+		return node, fmt.Errorf("cannot set field %q on nil value: %w: %w", decodedToken, ErrNilTraversal, ErrPointer)
 	}
 
-	if ns, ok := node.(JSONSetable); ok {
-		return node, ns.JSONSet(decodedToken, data)
+	switch typed := node.(type) {
+	case JSONSetable:
+		return node, typed.JSONSet(decodedToken, data)
+	case map[string]any:
+		return node, setInJSONObject(&typed, data, decodedToken, o)
+	case *map[string]any:
+		return node, setInJSONObject(typed, data, decodedToken, o)
+	case []any:
+		if isAppendToken(decodedToken, len(typed), o) {
+			if err := checkSetMode(false, decodedToken, o); err != nil {
+				return node, err
+			}
+			// the slice is passed by value: the new slice header is returned for the parent to rebind
+			return append(typed, data), nil
+		}
+		return node, setInJSONArray(typed, data, decodedToken, o)
+	case *[]any:
+		if isAppendToken(decodedToken, len(*typed), o) {
+			if err := checkSetMode(false, decodedToken, o); err != nil {
+				return node, err
+			}
+			*typed = append(*typed, data)
+			return node, nil
+		}
+		return node, setInJSONArray(*typed, data, decodedToken, o)
 	}
 
 	rValue := reflect.Indirect(reflect.ValueOf(node))
 
 	switch rValue.Kind() {
 	case reflect.Struct:
-		nm, ok := nameProvider.GetGoNameForType(rValue.Type(), decodedToken)
-		if !ok {
-			return node, fmt.Errorf("object has no field %q: %w", decodedToken, ErrPointer)
+		return node, setInStruct(rValue, data, decodedToken, o)
+
+	case reflect.Map:
+		return node, setInMap(rValue, data, decodedToken, o)
+
+	case reflect.Slice:
+		if isAppendToken(decodedToken, rValue.Len(), o) {
+			return appendToSlice(node, rValue, data, decodedToken, o)
 		}
 
-		fld := rValue.FieldByName(nm)
-		if !fld.CanSet() {
-			return node, fmt.Errorf("can't set struct field %s to %v: %w", nm, data, ErrPointer)
+		return node, setInArray(rValue, data, decodedToken, o)
+
+	case reflect.Array:
+		if decodedToken == dashToken {
+			return node, errDashOnArray()
 		}
 
-		value := reflect.ValueOf(data)
-		valueType := value.Type()
-		assignedType := fld.Type()
+		return node, setInArray(rValue, data, decodedToken, o)
+
+	default:
+		return node, errInvalidReference(decodedToken)
+	}
+}
+
+// Reflection-based helpers for [setSingleImpl], by kind of node.
+
+func setInStruct(rValue reflect.Value, data any, decodedToken string, o options) error {
+	fld, nm, err := structField(rValue, decodedToken, o)
+	if err != nil {
+		return err
+	}
+
+	if !fld.CanSet() {
+		return fmt.Errorf("can't set struct field %s to %v: %w: %w", nm, data, ErrNotSettable, ErrPointer)
+	}
+
+	if err := checkSetMode(true, decodedToken, o); err != nil {
+		return err
+	}
+
+	assignedType := fld.Type()
+	value, ok := assignableValue(data, assignedType, o)
+	if !ok {
+		return fmt.Errorf("can't set value with type %T to field %s with type %v: %w: %w", data, nm, assignedType, ErrTypeMismatch, ErrPointer)
+	}
+
+	fld.Set(value)
+
+	return nil
+}
+
+func setInMap(rValue reflect.Value, data any, decodedToken string, o options) error {
+	kv, err := mapKey(rValue.Type(), decodedToken)
+	if err != nil {
+		return err
+	}
+
+	if err := checkSetMode(rValue.MapIndex(kv).IsValid(), decodedToken, o); err != nil {
+		return err
+	}
+
+	assignedType := rValue.Type().Elem()
+	value, ok := assignableValue(data, assignedType, o)
+	if !ok {
+		return fmt.Errorf("can't set value with type %T to map entry %q with type %v: %w: %w", data, decodedToken, assignedType, ErrTypeMismatch, ErrPointer)
+	}
+
+	rValue.SetMapIndex(kv, value)
+
+	return nil
+}
+
+// appendToSlice implements the RFC 6901 §4 / RFC 6902 append semantics: terminal "-" appends the
+// value to the slice.
+//
+// We rebind in place when the slice is reachable via an addressable ancestor; otherwise we return
+// the new slice header for the parent (or the public Set) to rebind.
+func appendToSlice(node any, rValue reflect.Value, data any, decodedToken string, o options) (any, error) {
+	if err := checkSetMode(false, decodedToken, o); err != nil {
+		return node, err
+	}
+
+	elemType := rValue.Type().Elem()
+	value, ok := assignableValue(data, elemType, o)
+	if !ok {
+		return node, fmt.Errorf("can't append value of type %T to slice of %v: %w: %w", data, elemType, ErrTypeMismatch, ErrPointer)
+	}
+
+	newSlice := reflect.Append(rValue, value)
+	if rValue.CanSet() {
+		rValue.Set(newSlice)
+
+		return node, nil
+	}
+
+	return newSlice.Interface(), nil
+}
+
+// setInArray sets an existing element of a slice or a go array.
+func setInArray(rValue reflect.Value, data any, decodedToken string, o options) error {
+	tokenIndex, err := parseIndex(decodedToken, o.strict)
+	if err != nil {
+		return err
+	}
+
+	sLength := rValue.Len()
+	if tokenIndex < 0 || tokenIndex >= sLength {
+		return errOutOfBounds(sLength, tokenIndex)
+	}
+
+	elem := rValue.Index(tokenIndex)
+	if !elem.CanSet() {
+		return fmt.Errorf("can't set %v index %s to %v: %w: %w", rValue.Kind(), decodedToken, data, ErrNotSettable, ErrPointer)
+	}
+
+	if err := checkSetMode(true, decodedToken, o); err != nil {
+		return err
+	}
+
+	assignedType := elem.Type()
+	value, ok := assignableValue(data, assignedType, o)
+	if !ok {
+		return fmt.Errorf("can't set value with type %T to %v element %d with type %v: %w: %w", data, rValue.Kind(), tokenIndex, assignedType, ErrTypeMismatch, ErrPointer)
+	}
+
+	elem.Set(value)
+
+	return nil
+}
+
+func (p *Pointer) remove(node any, o options) (any, error) {
+	if p.strict {
+		o.strict = true
+	}
+	o.createMissing = false
+
+	if err := checkMutable(node); err != nil {
+		return node, p.errOn(opDelete, nodeKind(node), err)
+	}
+
+	if len(p.referenceTokens) == 0 {
+		return node, p.errOn(opDelete, nodeKind(node), errRemoveRoot())
+	}
+
+	if o.provider == nil {
+		o.provider = defaultOptions.provider
+	}
+
+	var buf [decodedTokensBufferSize]string
 
-		if !valueType.AssignableTo(assignedType) {
-			return node, fmt.Errorf("can't set value with type %T to field %s with type %v: %w", data, nm, assignedType, ErrPointer)
+	return p.mutateAt(node, p.appendDecodedTokens(buf[:0]), opDelete, o, func(parent any, decodedToken string) (any, error) {
+		return removeSingleImpl(parent, decodedToken, o)
+	})
+}
+
+func (p *Pointer) insert(node, data any, o options) (any, error) {
+	if p.strict {
+		o.strict = true
+	}
+
+	if err := checkMutable(node); err != nil {
+		return node, p.errOn(opInsert, nodeKind(node), err)
+	}
+
+	// full document when empty
+	if len(p.referenceTokens) == 0 {
+		return node, nil
+	}
+
+	if o.provider == nil {
+		o.provider = defaultOptions.provider
+	}
+
+	var buf [decodedTokensBufferSize]string
+
+	return p.mutateAt(node, p.appendDecodedTokens(buf[:0]), opInsert, o, func(parent any, decodedToken string) (any, error) {
+		return insertSingleImpl(parent, data, decodedToken, o)
+	})
+}
+
+// removeSingleImpl removes the value at decodedToken from node.
+//
+// See [Pointer.Delete] for the semantics of removal.
+//
+// Like the "-" append in [setSingleImpl], a slice that cannot be shortened in place is returned as a
+// new slice header for the parent to rebind. In that case, the original backing array is left
+// untouched.
+func removeSingleImpl(node any, decodedToken string, o options) (any, error) {
+	if isNil(node) {
+		return node, fmt.Errorf("cannot remove field %q from nil value: %w: %w", decodedToken, ErrNilTraversal, ErrPointer)
+	}
+
+	if nd, ok := node.(JSONDeletable); ok {
+		return node, nd.JSONDelete(decodedToken)
+	}
+
+	rValue := reflect.Indirect(reflect.ValueOf(node))
+
+	switch rValue.Kind() {
+	case reflect.Struct:
+		fld, nm, err := structField(rValue, decodedToken, o)
+		if err != nil {
+			return node, err
+		}
+
+		if !fld.CanSet() {
+			return node, fmt.Errorf("can't remove struct field %s: %w: %w", nm, ErrNotSettable, ErrPointer)
 		}
 
-		fld.Set(value)
+		fld.SetZero()
 
 		return node, nil
 
 	case reflect.Map:
-		kv := reflect.ValueOf(decodedToken)
-		rValue.SetMapIndex(kv, reflect.ValueOf(data))
+		kv, err := mapKey(rValue.Type(), decodedToken)
+		if err != nil {
+			return node, err
+		}
+
+		if !rValue.MapIndex(kv).IsValid() {
+			return node, errNoKey(decodedToken)
+		}
+
+		rValue.SetMapIndex(kv, reflect.Value{})
 
 		return node, nil
 
+	case reflect.Array:
+		return node, errFixedSizeArray("remove", decodedToken)
+
 	case reflect.Slice:
 		if decodedToken == dashToken {
-			// RFC 6901 §4 / RFC 6902 append semantics: terminal "-" appends the value to the slice.
-			//
-			// We rebind in place when the slice is reachable via an addressable ancestor; otherwise we
-			// return the new slice header for the parent (or the public Set) to rebind.
-			value := reflect.ValueOf(data)
-			elemType := rValue.Type().Elem()
-			if !value.Type().AssignableTo(elemType) {
-				return node, fmt.Errorf("can't append value of type %T to slice of %v: %w", data, elemType, ErrPointer)
-			}
-			newSlice := reflect.Append(rValue, value)
-			if rValue.CanSet() {
-				rValue.Set(newSlice)
-				return node, nil
-			}
-			return newSlice.Interface(), nil
+			return node, errDashOnRemove()
 		}
 
-		tokenIndex, err := strconv.Atoi(decodedToken)
+		tokenIndex, err := parseIndex(decodedToken, o.strict)
 		if err != nil {
-			return node, errors.Join(err, ErrPointer)
+			return node, err
 		}
 
 		sLength := rValue.Len()
@@ -612,28 +1505,90 @@ func setSingleImpl(node, data any, decodedToken string, nameProvider NameProvide
 			return node, errOutOfBounds(sLength, tokenIndex)
 		}
 
-		elem := rValue.Index(tokenIndex)
-		if !elem.CanSet() {
-			return node, fmt.Errorf("can't set slice index %s to %v: %w", decodedToken, data, ErrPointer)
-		}
-
-		value := reflect.ValueOf(data)
-		valueType := value.Type()
-		assignedType := elem.Type()
+		if rValue.CanSet() {
+			reflect.Copy(rValue.Slice(tokenIndex, sLength), rValue.Slice(tokenIndex+1, sLength))
+			rValue.Index(sLength - 1).SetZero()
+			rValue.SetLen(sLength - 1)
 
-		if !valueType.AssignableTo(assignedType) {
-			return node, fmt.Errorf("can't set value with type %T to slice element %d with type %v: %w", data, tokenIndex, assignedType, ErrPointer)
+			return node, nil
 		}
 
-		elem.Set(value)
+		newSlice := reflect.MakeSlice(rValue.Type(), sLength-1, sLength-1)
+		reflect.Copy(newSlice, rValue.Slice(0, tokenIndex))
+		reflect.Copy(newSlice.Slice(tokenIndex, sLength-1), rValue.Slice(tokenIndex+1, sLength))
 
-		return node, nil
+		return newSlice.Interface(), nil
 
 	default:
 		return node, errInvalidReference(decodedToken)
 	}
 }
 
+// insertSingleImpl sets data at decodedToken with the RFC 6902 "add" semantics.
+//
+// For slices, a numeric token inserts data before the element at that index, shifting subsequent
+// elements up. The index may be equal to the length of the slice, in which case data is appended.
+//
+// For all other kinds of node, this is equivalent to [setSingleImpl].
+func insertSingleImpl(node, data any, decodedToken string, o options) (any, error) {
+	if isNil(node) {
+		return node, fmt.Errorf("cannot set field %q on nil value: %w: %w", decodedToken, ErrNilTraversal, ErrPointer)
+	}
+
+	if _, ok := node.(JSONSetable); ok {
+		return setSingleImpl(node, data, decodedToken, o)
+	}
+
+	rValue := reflect.Indirect(reflect.ValueOf(node))
+	if rValue.Kind() == reflect.Array {
+		if decodedToken == dashToken {
+			return node, errDashOnArray()
+		}
+
+		return node, errFixedSizeArray("insert", decodedToken)
+	}
+
+	if rValue.Kind() != reflect.Slice || decodedToken == dashToken {
+		return setSingleImpl(node, data, decodedToken, o)
+	}
+
+	tokenIndex, err := parseIndex(decodedToken, o.strict)
+	if err != nil {
+		return node, err
+	}
+
+	sLength := rValue.Len()
+	if tokenIndex < 0 || tokenIndex > sLength {
+		return node, errOutOfBounds(sLength+1, tokenIndex)
+	}
+
+	if err := checkSetMode(false, decodedToken, o); err != nil {
+		return node, err
+	}
+
+	elemType := rValue.Type().Elem()
+	value, ok := assignableValue(data, elemType, o)
+	if !ok {
+		return node, fmt.Errorf("can't insert value of type %T into slice of %v: %w: %w", data, elemType, ErrTypeMismatch, ErrPointer)
+	}
+
+	if rValue.CanSet() {
+		grown := reflect.Append(rValue, reflect.Zero(elemType))
+		reflect.Copy(grown.Slice(tokenIndex+1, sLength+1), grown.Slice(tokenIndex, sLength))
+		grown.Index(tokenIndex).Set(value)
+		rValue.Set(grown)
+
+		return node, nil
+	}
+
+	newSlice := reflect.MakeSlice(rValue.Type(), sLength+1, sLength+1)
+	reflect.Copy(newSlice, rValue.Slice(0, tokenIndex))
+	newSlice.Index(tokenIndex).Set(value)
+	reflect.Copy(newSlice.Slice(tokenIndex+1, sLength+1), rValue.Slice(tokenIndex, sLength))
+
+	return newSlice.Interface(), nil
+}
+
 func offsetSingleObject(dec *json.Decoder, decodedToken string) (int64, error) {
 	for dec.More() {
 		offset := dec.InputOffset()
@@ -665,16 +1620,19 @@ func offsetSingleObject(dec *json.Decoder, decodedToken string) (int64, error) {
 		}
 	}
 
-	return 0, fmt.Errorf("token reference %q not found: %w", decodedToken, ErrPointer)
+	return 0, fmt.Errorf("token reference %q not found: %w: %w", decodedToken, ErrNotFound, ErrPointer)
 }
 
-func offsetSingleArray(dec *json.Decoder, decodedToken string) (int64, error) {
+func offsetSingleArray(dec *json.Decoder, decodedToken string, strict bool) (int64, error) {
 	if decodedToken == dashToken {
 		return 0, errDashOnOffset()
 	}
+	if strict && !isArrayIndex(decodedToken) {
+		return 0, errInvalidIndex(decodedToken)
+	}
 	idx, err := strconv.Atoi(decodedToken)
 	if err != nil {
-		return 0, fmt.Errorf("token reference %q is not a number: %w: %w", decodedToken, err, ErrPointer)
+		return 0, errNotANumber(decodedToken, err)
 	}
 	var i int
 	for i = 0; i < idx && dec.More(); i++ {
@@ -698,7 +1656,7 @@ func offsetSingleArray(dec *json.Decoder, decodedToken string) (int64, error) {
 	}
 
 	if !dec.More() {
-		return 0, fmt.Errorf("token reference %q not found: %w", decodedToken, ErrPointer)
+		return 0, fmt.Errorf("token reference %q not found: %w: %w", decodedToken, ErrIndexOutOfRange, ErrPointer)
 	}
 
 	return dec.InputOffset(), nil
@@ -751,6 +1709,11 @@ var (
 
 // Unescape unescapes a json pointer reference token string to the original representation.
 func Unescape(token string) string {
+	if !strings.Contains(token, decRefTok0) {
+		// fast path: nothing to unescape
+		return token
+	}
+
 	return encRefTokReplacer.Replace(token)
 }
 
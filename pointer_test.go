@@ -5,9 +5,11 @@ package jsonpointer
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/go-openapi/jsonpointer/jsonname"
@@ -98,7 +100,7 @@ func TestFullDocument(t *testing.T) {
 		})
 
 		t.Run("should resolve full doc, with nil name provider", func(t *testing.T) {
-			result, _, err := p.get(testDocumentJSON(t), nil)
+			result, _, err := p.get(testDocumentJSON(t), options{})
 			require.NoErrorf(t, err, "Get(%v) error %v", in, err)
 
 			asMap, ok := result.(map[string]any)
@@ -110,7 +112,7 @@ func TestFullDocument(t *testing.T) {
 				require.NoErrorf(t, err, "New(%v) error %v", in, err)
 
 				const value = "hey"
-				_, err = setter.set(asMap, value, nil)
+				_, err = setter.set(asMap, value, options{})
 				require.NoError(t, err)
 
 				foos, ok := asMap["foo"]
@@ -1147,7 +1149,7 @@ func TestInternalEdgeCases(t *testing.T) {
 	t.Run("setSingleImpl should error on any node not a struct, map or slice", func(t *testing.T) {
 		var node int
 
-		_, err := setSingleImpl(&node, 3, "a", jsonname.DefaultJSONNameProvider)
+		_, err := setSingleImpl(&node, 3, "a", options{provider: jsonname.DefaultJSONNameProvider})
 		require.Error(t, err)
 		require.ErrorContains(t, err, `invalid token reference "a"`)
 	})
@@ -1163,7 +1165,7 @@ func TestInternalEdgeCases(t *testing.T) {
 		t.Run("setSingleImpl should error on struct field that is not settable", func(t *testing.T) {
 			node := doc // doesn't pass a pointer: unsettable
 
-			_, err := setSingleImpl(node, "new value", "a", jsonname.DefaultJSONNameProvider)
+			_, err := setSingleImpl(node, "new value", "a", options{provider: jsonname.DefaultJSONNameProvider})
 			require.Error(t, err)
 			require.ErrorContains(t, err, `can't set struct field`)
 		})
@@ -1250,3 +1252,447 @@ func TestSetIntermediateErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestJSONDocument(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should get through pointers to JSON objects and arrays", func(t *testing.T) {
+		arr := []any{"a", "b"}
+		obj := map[string]any{"arr": &arr}
+
+		p, err := New("/arr/1")
+		require.NoError(t, err)
+
+		value, kind, err := p.Get(&obj)
+		require.NoError(t, err)
+		assert.Equal(t, "b", value)
+		assert.EqualT(t, reflect.String, kind)
+	})
+
+	t.Run("should set a nil value", func(t *testing.T) {
+		doc := map[string]any{"a": []any{1, 2}}
+
+		p, err := New("/a/0")
+		require.NoError(t, err)
+
+		_, err = p.Set(doc, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []any{nil, 2}, doc["a"])
+	})
+
+	t.Run("should initialize a nil map through a pointer", func(t *testing.T) {
+		var doc map[string]any
+
+		p, err := New("/a")
+		require.NoError(t, err)
+
+		_, err = p.Set(&doc, 1)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"a": 1}, doc)
+	})
+
+	t.Run("should append in place through a pointer", func(t *testing.T) {
+		doc := []any{1}
+
+		p, err := New("/-")
+		require.NoError(t, err)
+
+		out, err := p.Set(&doc, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []any{1, 2}, doc)
+		assert.Equal(t, &doc, out)
+	})
+
+	t.Run("should rebind an append nested in arrays", func(t *testing.T) {
+		doc := map[string]any{"a": []any{[]any{1}}}
+
+		p, err := New("/a/0/-")
+		require.NoError(t, err)
+
+		_, err = p.Set(doc, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []any{[]any{1, 2}}, doc["a"])
+	})
+
+	t.Run("should report errors", func(t *testing.T) {
+		doc := map[string]any{"a": []any{1}}
+
+		for _, input := range []string{"/b", "/a/1", "/a/-", "/a/x", "/a/-/b", "/b/c"} {
+			p, err := New(input)
+			require.NoError(t, err)
+
+			_, _, err = p.Get(doc)
+			require.ErrorIs(t, err, ErrPointer, "input: %q", input)
+		}
+
+		p, err := New("/a/-/b")
+		require.NoError(t, err)
+
+		_, err = p.Set(doc, 1)
+		require.ErrorIs(t, err, ErrDashToken)
+	})
+}
+
+func TestGoArrays(t *testing.T) {
+	t.Parallel()
+
+	type arrayDoc struct {
+		Matrix [3][3]float64 `json:"matrix"`
+		Items  [2]struct {
+			V int `json:"v"`
+		} `json:"items"`
+	}
+
+	newDoc := func() *arrayDoc {
+		doc := &arrayDoc{}
+		doc.Matrix[0][1] = 1.5
+		doc.Items[1].V = 2
+
+		return doc
+	}
+
+	t.Run("should get array elements", func(t *testing.T) {
+		for _, doc := range []any{newDoc(), *newDoc()} {
+			p, err := New("/matrix/0/1")
+			require.NoError(t, err)
+
+			value, kind, err := p.Get(doc)
+			require.NoError(t, err)
+			assert.Equal(t, 1.5, value)
+			assert.EqualT(t, reflect.Float64, kind)
+
+			p, err = New("/items/1/v")
+			require.NoError(t, err)
+
+			value, _, err = p.Get(doc)
+			require.NoError(t, err)
+			assert.Equal(t, 2, value)
+		}
+	})
+
+	t.Run("should set elements of an addressable array", func(t *testing.T) {
+		doc := newDoc()
+
+		p, err := New("/matrix/2/2")
+		require.NoError(t, err)
+
+		_, err = p.Set(doc, 9.0)
+		require.NoError(t, err)
+		assert.EqualT(t, 9.0, doc.Matrix[2][2])
+
+		p, err = New("/items/0/v")
+		require.NoError(t, err)
+
+		_, err = p.Set(doc, 3)
+		require.NoError(t, err)
+		assert.EqualT(t, 3, doc.Items[0].V)
+	})
+
+	t.Run("should compute the offset in the equivalent JSON", func(t *testing.T) {
+		raw, err := json.Marshal(newDoc())
+		require.NoError(t, err)
+
+		p, err := New("/matrix/0/1")
+		require.NoError(t, err)
+
+		offset, err := p.Offset(string(raw))
+		require.NoError(t, err)
+		assert.EqualT(t, "1.5", string(raw[offset:offset+3]))
+	})
+
+	t.Run("should report errors", func(t *testing.T) {
+		cases := []struct {
+			name   string
+			run    func() error
+			target error
+			substr string
+		}{
+			{
+				name: "dash token on set",
+				run: func() error {
+					_, err := SetForToken(&[2]int{}, "-", 1)
+					return err
+				},
+				target: ErrDashToken,
+				substr: "fixed size",
+			},
+			{
+				name: "dash token on get",
+				run: func() error {
+					_, _, err := GetForToken([2]int{}, "-")
+					return err
+				},
+				target: ErrDashToken,
+			},
+			{
+				name: "out of bounds",
+				run: func() error {
+					_, _, err := GetForToken([2]int{}, "2")
+					return err
+				},
+				target: ErrPointer,
+				substr: "out of bounds",
+			},
+			{
+				name: "non-addressable array",
+				run: func() error {
+					_, err := SetForToken([2]int{}, "0", 1)
+					return err
+				},
+				target: ErrPointer,
+				substr: "can't set array index 0",
+			},
+			{
+				name: "remove",
+				run: func() error {
+					_, err := RemoveForToken(&[2]int{}, "0")
+					return err
+				},
+				target: ErrPointer,
+				substr: "fixed size",
+			},
+			{
+				name: "insert",
+				run: func() error {
+					_, err := InsertForToken(&[2]int{}, "0", 1)
+					return err
+				},
+				target: ErrPointer,
+				substr: "fixed size",
+			},
+		}
+
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				err := tt.run()
+				require.Error(t, err)
+				require.ErrorIs(t, err, tt.target)
+				if tt.substr != "" {
+					require.ErrorContains(t, err, tt.substr)
+				}
+			})
+		}
+	})
+}
+
+// upperKey is a map key type that decodes its text representation in upper case.
+type upperKey string
+
+func (k *upperKey) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		return errors.New("empty key")
+	}
+
+	*k = upperKey(strings.ToUpper(string(text)))
+
+	return nil
+}
+
+func TestMapKeys(t *testing.T) {
+	t.Parallel()
+
+	type namedKey string
+
+	t.Run("should get with keys of integer kinds", func(t *testing.T) {
+		value, _, err := GetForToken(map[int]string{-1: "a"}, "-1")
+		require.NoError(t, err)
+		assert.Equal(t, "a", value)
+
+		value, _, err = GetForToken(map[uint8]string{255: "b"}, "255")
+		require.NoError(t, err)
+		assert.Equal(t, "b", value)
+	})
+
+	t.Run("should get with keys of named string types", func(t *testing.T) {
+		value, _, err := GetForToken(map[namedKey]int{"k": 1}, "k")
+		require.NoError(t, err)
+		assert.Equal(t, 1, value)
+	})
+
+	t.Run("should get with keys implementing encoding.TextUnmarshaler", func(t *testing.T) {
+		value, _, err := GetForToken(map[upperKey]int{"K": 1}, "k")
+		require.NoError(t, err)
+		assert.Equal(t, 1, value)
+	})
+
+	t.Run("should set, rebind and delete", func(t *testing.T) {
+		doc := map[string]any{
+			"ints":  map[int][]int{1: {1}},
+			"named": map[namedKey]int{},
+		}
+
+		p, err := New("/ints/1/-")
+		require.NoError(t, err)
+		_, err = p.Set(doc, 2)
+		require.NoError(t, err)
+		assert.Equal(t, map[int][]int{1: {1, 2}}, doc["ints"])
+
+		p, err = New("/named/k")
+		require.NoError(t, err)
+		_, err = p.Set(doc, 3)
+		require.NoError(t, err)
+		assert.Equal(t, map[namedKey]int{"k": 3}, doc["named"])
+
+		p, err = New("/ints/1")
+		require.NoError(t, err)
+		_, err = p.Delete(doc)
+		require.NoError(t, err)
+		assert.Equal(t, map[int][]int{}, doc["ints"])
+	})
+
+	t.Run("should report tokens that do not convert to a key", func(t *testing.T) {
+		cases := []struct {
+			name string
+			doc  any
+			key  string
+		}{
+			{name: "not an integer", doc: map[int]int{}, key: "x"},
+			{name: "overflow", doc: map[uint8]int{}, key: "256"},
+			{name: "negative unsigned", doc: map[uint]int{}, key: "-1"},
+			{name: "unsupported key kind", doc: map[float64]int{}, key: "1"},
+			{name: "TextUnmarshaler error", doc: map[upperKey]int{}, key: ""},
+		}
+
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				_, _, err := GetForToken(tt.doc, tt.key)
+				require.ErrorIs(t, err, ErrTypeMismatch)
+				require.ErrorIs(t, err, ErrPointer)
+
+				_, err = SetForToken(tt.doc, tt.key, 1)
+				require.ErrorIs(t, err, ErrTypeMismatch)
+
+				_, err = RemoveForToken(tt.doc, tt.key)
+				require.ErrorIs(t, err, ErrTypeMismatch)
+			})
+		}
+	})
+}
+
+func TestCopyOnWrite(t *testing.T) {
+	t.Parallel()
+
+	type point struct {
+		X int `json:"x"`
+		Y int `json:"y"`
+	}
+
+	type holder struct {
+		Any any              `json:"any"`
+		Map map[string]point `json:"map"`
+	}
+
+	t.Run("should set a field of a struct stored by value in a map[string]any", func(t *testing.T) {
+		doc := map[string]any{"p": point{X: 1}}
+
+		p, err := New("/p/y")
+		require.NoError(t, err)
+
+		_, err = p.Set(doc, 2)
+		require.NoError(t, err)
+		assert.Equal(t, point{X: 1, Y: 2}, doc["p"])
+	})
+
+	t.Run("should set a field of a struct stored by value in a []any", func(t *testing.T) {
+		doc := map[string]any{"points": []any{point{}, point{X: 1}}}
+
+		p, err := New("/points/1/y")
+		require.NoError(t, err)
+
+		_, err = p.Set(doc, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []any{point{}, point{X: 1, Y: 2}}, doc["points"])
+	})
+
+	t.Run("should set through typed maps and fields of type any", func(t *testing.T) {
+		doc := &holder{
+			Any: map[string]any{"inner": point{}},
+			Map: map[string]point{"a": {}},
+		}
+
+		p, err := New("/any/inner/x")
+		require.NoError(t, err)
+		_, err = p.Set(doc, 3)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"inner": point{X: 3}}, doc.Any)
+
+		p, err = New("/map/a/y")
+		require.NoError(t, err)
+		_, err = p.Set(doc, 4)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]point{"a": {Y: 4}}, doc.Map)
+	})
+
+	t.Run("should set deep inside nested values", func(t *testing.T) {
+		doc := map[string]any{"h": holder{Any: point{}}}
+
+		p, err := New("/h/any/x")
+		require.NoError(t, err)
+
+		_, err = p.Set(doc, 5)
+		require.NoError(t, err)
+		assert.Equal(t, holder{Any: point{X: 5}}, doc["h"])
+	})
+
+	t.Run("should set an element of an array stored by value", func(t *testing.T) {
+		doc := map[string]any{"a": [2]int{1, 2}}
+
+		p, err := New("/a/1")
+		require.NoError(t, err)
+
+		_, err = p.Set(doc, 3)
+		require.NoError(t, err)
+		assert.Equal(t, [2]int{1, 3}, doc["a"])
+	})
+
+	t.Run("should delete a field", func(t *testing.T) {
+		doc := map[string]any{"p": point{X: 1, Y: 2}}
+
+		p, err := New("/p/x")
+		require.NoError(t, err)
+
+		_, err = p.Delete(doc)
+		require.NoError(t, err)
+		assert.Equal(t, point{Y: 2}, doc["p"])
+	})
+
+	t.Run("should not set a field of a struct in a root struct passed by value", func(t *testing.T) {
+		type outer struct {
+			In point `json:"in"`
+		}
+
+		p, err := New("/in/x")
+		require.NoError(t, err)
+
+		_, err = p.Set(outer{}, 5)
+		require.ErrorIs(t, err, ErrNotSettable)
+
+		_, err = p.Delete(outer{In: point{X: 1}})
+		require.ErrorIs(t, err, ErrNotSettable)
+	})
+
+	t.Run("should not set a field of a struct in a root array passed by value", func(t *testing.T) {
+		p, err := New("/0/x")
+		require.NoError(t, err)
+
+		_, err = p.Set([2]point{}, 5)
+		require.ErrorIs(t, err, ErrNotSettable)
+
+		doc := [2]point{}
+		_, err = p.Set(&doc, 5)
+		require.NoError(t, err)
+		assert.Equal(t, [2]point{{X: 5}}, doc)
+	})
+
+	t.Run("should leave the original value untouched on error", func(t *testing.T) {
+		original := point{X: 1}
+		doc := map[string]any{"p": original}
+
+		p, err := New("/p/x")
+		require.NoError(t, err)
+
+		_, err = p.Set(doc, "not an int")
+		require.Error(t, err)
+		assert.Equal(t, original, doc["p"])
+	})
+}
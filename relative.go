@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpointer
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const nameOfToken = `#`
+
+// RelativePointer is a representation of a relative json pointer, as defined by
+// draft-bhutton-relative-json-pointer.
+//
+// A relative json pointer is evaluated against a document from a current location, expressed as a
+// (absolute) [Pointer]. It consists of:
+//
+//   - a non-negative integer, which tells how many levels to walk up from the current location
+//   - an optional index manipulation (e.g. "+1" or "-2"), which shifts the array index reached
+//     after walking up
+//   - either a json pointer, which is resolved from there, or the "#" character, which yields the
+//     key (or the array index) of the location reached, rather than its value
+//
+// Examples: "0", "1/foo", "0-1", "2#".
+type RelativePointer struct {
+	up      int
+	shift   int
+	sign    byte // '+' or '-' when the index is manipulated, possibly by zero
+	nameOf  bool
+	pointer Pointer
+}
+
+// NewRelative creates a new relative json pointer from its string representation.
+func NewRelative(relativePointerString string) (RelativePointer, error) {
+	var r RelativePointer
+	err := r.parse(relativePointerString)
+
+	return r, err
+}
+
+// String representation of a relative pointer.
+func (r RelativePointer) String() string {
+	var b strings.Builder
+
+	b.WriteString(strconv.Itoa(r.up))
+	if r.sign != 0 {
+		b.WriteByte(r.sign)
+		b.WriteString(strconv.Itoa(max(r.shift, -r.shift)))
+	}
+
+	if r.nameOf {
+		b.WriteString(nameOfToken)
+
+		return b.String()
+	}
+
+	b.WriteString(r.pointer.String())
+
+	return b.String()
+}
+
+// Evaluate resolves the relative pointer against a JSON document, starting from the location
+// designated by base.
+//
+// Like [Pointer.Get], it returns the value with its type as a [reflect.Kind] or an error.
+//
+// When the relative pointer ends with "#", the returned value is the key (as a string) or the index
+// (as an int) of the location reached after walking up from base.
+//
+// All errors wrap [ErrPointer].
+func (r RelativePointer) Evaluate(document any, base Pointer, opts ...Option) (any, reflect.Kind, error) {
+	o := optionsWithDefaults(opts)
+
+	tokens := base.DecodedTokens()
+	if r.up > len(tokens) {
+		return nil, reflect.Invalid, fmt.Errorf("cannot walk up %d levels from %q: %w", r.up, base.String(), ErrPointer)
+	}
+	tokens = tokens[:len(tokens)-r.up]
+
+	if r.sign != 0 || r.nameOf {
+		if len(tokens) == 0 {
+			return nil, reflect.Invalid, fmt.Errorf("the root document has no parent (relative pointer %q from %q): %w", r.String(), base.String(), ErrPointer)
+		}
+
+		parentPointer := FromTokens(tokens[:len(tokens)-1]...)
+		parent, _, err := parentPointer.get(document, o)
+		if err != nil {
+			return nil, reflect.Invalid, err
+		}
+
+		last := tokens[len(tokens)-1]
+		rParent := reflect.Indirect(reflect.ValueOf(parent))
+		isArray := rParent.Kind() == reflect.Slice || rParent.Kind() == reflect.Array
+
+		if r.sign != 0 {
+			if !isArray {
+				return nil, reflect.Invalid, errInvalidReference(last)
+			}
+
+			idx, err := r.shiftIndex(last, rParent.Len())
+			if err != nil {
+				return nil, reflect.Invalid, err
+			}
+
+			tokens[len(tokens)-1] = strconv.Itoa(idx)
+		}
+
+		if r.nameOf {
+			if isArray {
+				idx, err := strconv.Atoi(tokens[len(tokens)-1])
+				if err != nil {
+					return nil, reflect.Invalid, fmt.Errorf("%w: %w", err, ErrPointer)
+				}
+
+				return idx, reflect.Int, nil
+			}
+
+			return last, reflect.String, nil
+		}
+	}
+
+	target := FromTokens(append(tokens, r.pointer.DecodedTokens()...)...)
+
+	return target.get(document, o)
+}
+
+func (r *RelativePointer) shiftIndex(token string, length int) (int, error) {
+	idx, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", err, ErrPointer)
+	}
+
+	idx += r.shift
+	if idx < 0 || idx >= length {
+		return 0, errOutOfBounds(length, idx)
+	}
+
+	return idx, nil
+}
+
+// "Constructor", parses the given string relative JSON pointer.
+func (r *RelativePointer) parse(relativePointerString string) error {
+	up, rest, ok := parseNonNegativeInteger(relativePointerString)
+	if !ok {
+		return fmt.Errorf("relative JSON pointer %q must start with a non-negative integer: %w", relativePointerString, ErrPointer)
+	}
+	r.up = up
+
+	if len(rest) > 0 && (rest[0] == '+' || rest[0] == '-') {
+		r.sign = rest[0]
+
+		var shift int
+		shift, rest, ok = parseNonNegativeInteger(rest[1:])
+		if !ok {
+			return fmt.Errorf("invalid index manipulation in relative JSON pointer %q: %w", relativePointerString, ErrPointer)
+		}
+
+		r.shift = shift
+		if r.sign == '-' {
+			r.shift = -shift
+		}
+	}
+
+	if rest == nameOfToken {
+		r.nameOf = true
+
+		return nil
+	}
+
+	return r.pointer.parse(rest)
+}
+
+// parseNonNegativeInteger parses the leading non-negative integer of s, without leading zeros, and
+// returns the remainder of the string.
+func parseNonNegativeInteger(s string) (int, string, bool) {
+	end := 0
+	for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+		end++
+	}
+
+	if end == 0 || (end > 1 && s[0] == '0') {
+		return 0, s, false
+	}
+
+	n, err := strconv.Atoi(s[:end])
+	if err != nil {
+		return 0, s, false
+	}
+
+	return n, s[end:], true
+}
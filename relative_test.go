@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpointer
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+// example document from draft-bhutton-relative-json-pointer §5.1.
+const relativeTestDocument = `{
+  "foo": ["bar", "baz"],
+  "highly": {
+    "nested": {
+      "objects": true
+    }
+  }
+}`
+
+func TestRelativePointer_Evaluate(t *testing.T) {
+	t.Parallel()
+
+	var doc any
+	require.NoError(t, json.Unmarshal([]byte(relativeTestDocument), &doc))
+
+	cases := []struct {
+		base     string
+		relative string
+		expected any
+		kind     reflect.Kind
+	}{
+		{base: "/foo/1", relative: "0", expected: "baz", kind: reflect.String},
+		{base: "/foo/1", relative: "1/0", expected: "bar", kind: reflect.String},
+		{base: "/foo/1", relative: "0-1", expected: "bar", kind: reflect.String},
+		{base: "/foo/1", relative: "2/highly/nested/objects", expected: true, kind: reflect.Bool},
+		{base: "/foo/1", relative: "0#", expected: 1, kind: reflect.Int},
+		{base: "/foo/1", relative: "0-1#", expected: 0, kind: reflect.Int},
+		{base: "/foo/1", relative: "0+0", expected: "baz", kind: reflect.String},
+		{base: "/foo/1", relative: "0-0#", expected: 1, kind: reflect.Int},
+		{base: "/foo/1", relative: "1#", expected: "foo", kind: reflect.String},
+		{base: "/highly/nested", relative: "0/objects", expected: true, kind: reflect.Bool},
+		{base: "/highly/nested", relative: "1/nested/objects", expected: true, kind: reflect.Bool},
+		{base: "/highly/nested", relative: "2/foo/0", expected: "bar", kind: reflect.String},
+		{base: "/highly/nested", relative: "0#", expected: "nested", kind: reflect.String},
+		{base: "/highly/nested", relative: "1#", expected: "highly", kind: reflect.String},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.relative+" from "+tt.base, func(t *testing.T) {
+			base, err := New(tt.base)
+			require.NoError(t, err)
+
+			r, err := NewRelative(tt.relative)
+			require.NoError(t, err)
+			assert.EqualT(t, tt.relative, r.String())
+
+			value, kind, err := r.Evaluate(doc, base)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+			assert.EqualT(t, tt.kind, kind)
+		})
+	}
+
+	t.Run("with struct document", func(t *testing.T) {
+		base, err := New("/obj/d/1/f/0")
+		require.NoError(t, err)
+
+		r, err := NewRelative("2-1/e")
+		require.NoError(t, err)
+
+		value, _, err := r.Evaluate(testStructJSONDoc(t), base)
+		require.NoError(t, err)
+		assert.Equal(t, 9, value)
+	})
+}
+
+func TestRelativePointer_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should reject invalid relative pointers", func(t *testing.T) {
+		for _, input := range []string{"", "/foo", "01", "-1", "0+", "0+00", "0-01", "0#/foo", "0foo", "1##"} {
+			_, err := NewRelative(input)
+			require.Error(t, err, "input: %q", input)
+			require.ErrorIs(t, err, ErrPointer, "input: %q", input)
+		}
+	})
+
+	var doc any
+	require.NoError(t, json.Unmarshal([]byte(relativeTestDocument), &doc))
+
+	cases := []struct {
+		name     string
+		base     string
+		relative string
+	}{
+		{name: "walking above the root", base: "/foo/1", relative: "3"},
+		{name: "name of the root", base: "/foo", relative: "1#"},
+		{name: "index manipulation on an object member", base: "/highly/nested", relative: "0+1"},
+		{name: "zero index manipulation on an object member", base: "/highly/nested", relative: "0-0"},
+		{name: "index manipulation out of range", base: "/foo/1", relative: "0+1"},
+		{name: "index manipulation below zero", base: "/foo/0", relative: "0-1"},
+		{name: "missing target", base: "/foo/1", relative: "1/3"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			base, err := New(tt.base)
+			require.NoError(t, err)
+
+			r, err := NewRelative(tt.relative)
+			require.NoError(t, err)
+
+			_, _, err = r.Evaluate(doc, base)
+			require.Error(t, err)
+			require.ErrorIs(t, err, ErrPointer)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpointer
+
+import (
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestNewStrict(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should accept valid pointers", func(t *testing.T) {
+		for _, input := range []string{``, `/`, `/a~1b`, `/m~0n`, `/~01`, `/foo/0`, `/foo/-`} {
+			p, err := NewStrict(input)
+			require.NoError(t, err, "input: %q", input)
+			assert.EqualT(t, input, p.String())
+		}
+	})
+
+	t.Run("should reject invalid escape sequences with their position", func(t *testing.T) {
+		cases := []struct {
+			input    string
+			position string
+		}{
+			{input: `/a~2b`, position: "byte 2"},
+			{input: `/a~`, position: "byte 2"},
+			{input: `/a~0/~`, position: "byte 5"},
+			{input: `/~~1`, position: "byte 1"},
+		}
+
+		for _, tt := range cases {
+			_, err := NewStrict(tt.input)
+			require.Error(t, err, "input: %q", tt.input)
+			require.ErrorIs(t, err, ErrInvalidEscape)
+			require.ErrorIs(t, err, ErrPointer)
+			require.ErrorContains(t, err, tt.position)
+
+			_, err = New(tt.input)
+			require.NoError(t, err, "non-strict pointers should accept %q", tt.input)
+		}
+	})
+
+	t.Run("should still require a leading separator", func(t *testing.T) {
+		_, err := NewStrict("a")
+		require.ErrorIs(t, err, ErrInvalidStart)
+	})
+}
+
+func TestStrict_ArrayIndex(t *testing.T) {
+	t.Parallel()
+
+	for _, token := range []string{"+1", "-0", "01", "00", " 1"} {
+		t.Run("with index "+token, func(t *testing.T) {
+			strict, err := NewStrict("/foo/" + token)
+			require.NoError(t, err)
+
+			t.Run("should fail on Get", func(t *testing.T) {
+				_, _, err := strict.Get(testDocumentJSON(t))
+				require.ErrorIs(t, err, ErrInvalidIndex)
+				require.ErrorIs(t, err, ErrPointer)
+			})
+
+			t.Run("should fail on Set", func(t *testing.T) {
+				_, err := strict.Set(testDocumentJSON(t), "x")
+				require.ErrorIs(t, err, ErrInvalidIndex)
+			})
+
+			t.Run("should fail on intermediate Set", func(t *testing.T) {
+				intermediate, err := NewStrict("/obj/d/" + token + "/e")
+				require.NoError(t, err)
+
+				_, err = intermediate.Set(testStructJSONPtr(t), 1)
+				require.ErrorIs(t, err, ErrInvalidIndex)
+			})
+
+			t.Run("should fail on Delete", func(t *testing.T) {
+				_, err := strict.Delete(testDocumentJSON(t))
+				require.ErrorIs(t, err, ErrInvalidIndex)
+			})
+
+			t.Run("should fail on Offset", func(t *testing.T) {
+				_, err := strict.Offset(`{"foo": ["bar", "baz"]}`)
+				require.ErrorIs(t, err, ErrInvalidIndex)
+			})
+		})
+	}
+
+	t.Run("non-strict pointers accept non-canonical indices", func(t *testing.T) {
+		p, err := New("/foo/01")
+		require.NoError(t, err)
+
+		v, _, err := p.Get(testDocumentJSON(t))
+		require.NoError(t, err)
+		assert.Equal(t, "baz", v)
+	})
+
+	t.Run("strict pointers accept canonical indices", func(t *testing.T) {
+		p, err := NewStrict("/foo/1")
+		require.NoError(t, err)
+
+		v, _, err := p.Get(testDocumentJSON(t))
+		require.NoError(t, err)
+		assert.Equal(t, "baz", v)
+
+		offset, err := p.Offset(`{"foo": ["bar", "baz"]}`)
+		require.NoError(t, err)
+		assert.EqualT(t, int64(16), offset)
+	})
+
+	t.Run("strict mode does not apply to object keys", func(t *testing.T) {
+		p, err := NewStrict("/01")
+		require.NoError(t, err)
+
+		v, _, err := p.Get(map[string]any{"01": true})
+		require.NoError(t, err)
+		assert.Equal(t, true, v)
+	})
+}
@@ -110,8 +110,8 @@ func Example_struct() {
 	// a: a
 	// b: promoted
 	// c: c
-	// ignored: object has no field "ignored": JSON pointer error
-	// unexported: object has no field "unexported": JSON pointer error
-	// anonymous: object has no field "propB": JSON pointer error
-	// untagged: object has no field "untagged": JSON pointer error
+	// ignored: get "/ignored" at token 0: object has no field "ignored": JSON pointer location not found: JSON pointer error
+	// unexported: get "/unexported" at token 0: object has no field "unexported": JSON pointer location not found: JSON pointer error
+	// anonymous: get "/propB" at token 0: object has no field "propB": JSON pointer location not found: JSON pointer error
+	// untagged: get "/untagged" at token 0: object has no field "untagged": JSON pointer location not found: JSON pointer error
 }
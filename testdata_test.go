@@ -23,7 +23,7 @@ func testDocumentJSON(t *testing.T) any {
 	return document
 }
 
-func testStructJSONDoc(t *testing.T) testStructJSON {
+func testStructJSONDoc(t testing.TB) testStructJSON {
 	t.Helper()
 
 	var document testStructJSON
@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpointer
+
+import (
+	"reflect"
+	"sync"
+)
+
+// maxCachedPointers bounds the number of pointers kept by the parsing cache of [Lookup].
+const maxCachedPointers = 1024
+
+//nolint:gochecknoglobals // the parsing cache is shared by all calls to Lookup
+var lookupCache = &pointerCache{}
+
+// GetAs uses the pointer to retrieve a value from a JSON document, like [Pointer.Get], and converts
+// it to type T.
+//
+// Besides values that are directly assignable to T, GetAs supports the following conversions:
+//
+//   - numbers are converted to any numeric type, provided the conversion is exact. This includes
+//     the float64 values and the [encoding/json.Number] values produced by decoding JSON. For
+//     instance, 2.0 converts to an int, but 2.5 does not. Like with encoding/json, floating point
+//     numbers are rounded when T is float32
+//   - pointers are dereferenced, and values are wrapped into a pointer when T is a pointer type
+//   - strings and booleans are converted to named types with the same underlying type
+//   - a JSON null converts to the zero value of T when T is a pointer, an interface, a map or a
+//     slice type
+//
+// A value that cannot be converted is reported by an error wrapping [ErrTypeMismatch] and
+// [ErrPointer].
+func GetAs[T any](document any, pointer Pointer, opts ...Option) (T, error) {
+	var zero T
+
+	value, _, err := pointer.Get(document, opts...)
+	if err != nil {
+		return zero, err
+	}
+
+	return convertTo[T](value)
+}
+
+// SetAs uses the pointer to set a value in a JSON document, like [Pointer.Set], and returns the
+// updated document with its original type.
+//
+// This spares a type assertion when the returned document is load-bearing, e.g. when appending to
+// a top-level slice passed by value.
+func SetAs[D any](document D, pointer Pointer, value any, opts ...Option) (D, error) {
+	updated, err := pointer.Set(document, value, opts...)
+	if err != nil {
+		return document, err
+	}
+
+	typed, ok := updated.(D)
+	if !ok {
+		return document, errTypeMismatch(updated, reflect.TypeFor[D]())
+	}
+
+	return typed, nil
+}
+
+// Lookup parses the json pointer string and retrieves a value converted to type T, like [GetAs].
+//
+// Parsed pointers are cached, so that looking up the same pointer strings repeatedly does not parse
+// them again.
+func Lookup[T any](document any, jsonPointerString string, opts ...Option) (T, error) {
+	pointer, err := lookupCache.parse(jsonPointerString)
+	if err != nil {
+		var zero T
+
+		return zero, err
+	}
+
+	return GetAs[T](document, pointer, opts...)
+}
+
+func convertTo[T any](value any) (T, error) {
+	if typed, ok := value.(T); ok {
+		return typed, nil
+	}
+
+	var zero T
+
+	converted, err := convertValue(value, reflect.TypeFor[T]())
+	if err != nil {
+		return zero, err
+	}
+
+	// a nil interface does not pass the type assertion, which yields the zero value of T
+	typed, _ := converted.Interface().(T)
+
+	return typed, nil
+}
+
+// pointerCache keeps parsed pointers, up to [maxCachedPointers].
+//
+// When the cache is full, it is reset. Pointers are immutable, so a cached pointer may be shared
+// freely.
+type pointerCache struct {
+	mu       sync.RWMutex
+	pointers map[string]Pointer
+}
+
+func (c *pointerCache) parse(jsonPointerString string) (Pointer, error) {
+	c.mu.RLock()
+	pointer, ok := c.pointers[jsonPointerString]
+	c.mu.RUnlock()
+
+	if ok {
+		return pointer, nil
+	}
+
+	pointer, err := New(jsonPointerString)
+	if err != nil {
+		return pointer, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.pointers == nil || len(c.pointers) >= maxCachedPointers {
+		c.pointers = make(map[string]Pointer, maxCachedPointers)
+	}
+	c.pointers[jsonPointerString] = pointer
+
+	return pointer, nil
+}
@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpointer
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestGetAs(t *testing.T) {
+	t.Parallel()
+
+	doc := testDocumentJSON(t)
+
+	t.Run("should return a value of the expected type", func(t *testing.T) {
+		p, err := New("/foo/0")
+		require.NoError(t, err)
+
+		value, err := GetAs[string](doc, p)
+		require.NoError(t, err)
+		assert.EqualT(t, "bar", value)
+	})
+
+	t.Run("should convert JSON numbers", func(t *testing.T) {
+		p, err := New("/obj/a")
+		require.NoError(t, err)
+
+		value, err := GetAs[int](doc, p)
+		require.NoError(t, err)
+		assert.EqualT(t, 1, value)
+
+		ptr, err := GetAs[*uint8](doc, p)
+		require.NoError(t, err)
+		require.NotNil(t, ptr)
+		assert.EqualT(t, uint8(1), *ptr)
+	})
+
+	t.Run("should round floats into a float32 like encoding/json", func(t *testing.T) {
+		value, err := Lookup[float32](map[string]any{"f": 0.1}, "/f")
+		require.NoError(t, err)
+		assert.EqualT(t, float32(0.1), value)
+
+		_, err = Lookup[float32](map[string]any{"f": 1e300}, "/f")
+		require.ErrorIs(t, err, ErrTypeMismatch)
+	})
+
+	t.Run("should convert json.Number", func(t *testing.T) {
+		dec := json.NewDecoder(strings.NewReader(`{"n": 12, "f": 1.5}`))
+		dec.UseNumber()
+		var numbers any
+		require.NoError(t, dec.Decode(&numbers))
+
+		n, err := Lookup[int64](numbers, "/n")
+		require.NoError(t, err)
+		assert.EqualT(t, int64(12), n)
+
+		f, err := Lookup[float32](numbers, "/f")
+		require.NoError(t, err)
+		assert.EqualT(t, float32(1.5), f)
+
+		_, err = Lookup[int](numbers, "/f")
+		require.ErrorIs(t, err, ErrTypeMismatch)
+	})
+
+	t.Run("should dereference pointers", func(t *testing.T) {
+		s := "x"
+		value, err := Lookup[string](map[string]any{"a": &s}, "/a")
+		require.NoError(t, err)
+		assert.EqualT(t, "x", value)
+	})
+
+	t.Run("should return the zero value for null", func(t *testing.T) {
+		value, err := Lookup[map[string]any](map[string]any{"a": nil}, "/a")
+		require.NoError(t, err)
+		assert.Nil(t, value)
+
+		v, err := Lookup[any](map[string]any{"a": nil}, "/a")
+		require.NoError(t, err)
+		assert.Nil(t, v)
+	})
+
+	t.Run("should report a type mismatch", func(t *testing.T) {
+		p, err := New("/foo")
+		require.NoError(t, err)
+
+		_, err = GetAs[int](doc, p)
+		require.Error(t, err)
+		require.ErrorIs(t, err, ErrTypeMismatch)
+		require.ErrorIs(t, err, ErrPointer)
+		require.ErrorContains(t, err, "to int")
+	})
+
+	t.Run("should report pointer errors", func(t *testing.T) {
+		_, err := Lookup[int](doc, "/missing")
+		require.ErrorIs(t, err, ErrPointer)
+
+		_, err = Lookup[int](doc, "missing")
+		require.ErrorIs(t, err, ErrInvalidStart)
+	})
+}
+
+func TestSetAs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return the document with its type", func(t *testing.T) {
+		p, err := New("/-")
+		require.NoError(t, err)
+
+		doc, err := SetAs([]int{1}, p, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2}, doc)
+	})
+
+	t.Run("should return the original document on error", func(t *testing.T) {
+		p, err := New("/-")
+		require.NoError(t, err)
+
+		doc, err := SetAs([]int{1}, p, "x")
+		require.ErrorIs(t, err, ErrPointer)
+		assert.Equal(t, []int{1}, doc)
+	})
+}
+
+func TestLookupCache(t *testing.T) {
+	t.Parallel()
+
+	var cache pointerCache
+
+	for i := range maxCachedPointers + 1 {
+		p, err := cache.parse("/" + strings.Repeat("a", i))
+		require.NoError(t, err)
+		assert.EqualT(t, 1, p.Len())
+	}
+
+	cache.mu.RLock()
+	defer cache.mu.RUnlock()
+	assert.EqualT(t, 1, len(cache.pointers))
+}
@@ -0,0 +1,353 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpointer
+
+import (
+	"encoding"
+	"iter"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/jsonpointer/internal/identity"
+)
+
+//nolint:gochecknoglobals // it's okay to declare a reflect.Type as a private global
+var textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+
+// jsonNamesLister is implemented by name providers that know how to list all the json names of a
+// go struct, such as the providers of [github.com/go-openapi/jsonpointer/jsonname].
+type jsonNamesLister interface {
+	GetJSONNames(subject any) []string
+}
+
+// Walk iterates over all the locations of a document, yielding their pointer and value.
+//
+// Values are visited depth-first, with parents before their children, starting with the document
+// itself at the empty pointer. The document is traversed with the same rules as [Pointer.Get]:
+//
+//   - maps are objects, with keys converted to tokens (see [Pointer] for the supported key types).
+//     Maps with other key types are not traversed
+//   - slices and go arrays are arrays
+//   - structs are objects, with fields named by the configured [NameProvider]
+//   - values implementing [JSONPointable] are traversed like their underlying go type, but their
+//     children are resolved by [JSONPointable.JSONLookup]
+//
+// Struct fields are visited in declaration order, and array elements by increasing index. Map keys
+// are visited in no particular order, unless [WithSortedKeys] is used.
+//
+// Cycles in pointer graphs are detected: a value that is already being visited by one of its
+// ancestors is yielded, but not traversed again.
+//
+// The options [WithSortedKeys], [WithMaxDepth] and [WithLeavesOnly] tune the traversal.
+func Walk(document any, opts ...Option) iter.Seq2[Pointer, any] {
+	return walk(document, nil, opts)
+}
+
+func walk(document any, pattern *Pattern, opts []Option) iter.Seq2[Pointer, any] {
+	o := optionsWithDefaults(opts)
+	if o.provider == nil {
+		o.provider = defaultOptions.provider
+	}
+	o.fields = &fieldCache{} // struct fields are resolved once per type during the walk
+
+	return func(yield func(Pointer, any) bool) {
+		w := walker{
+			o:        o,
+			pattern:  pattern,
+			yield:    yield,
+			visiting: make(map[identity.Key]struct{}),
+		}
+
+		w.walk(document)
+	}
+}
+
+// walkChild is a child value of a node, along with its decoded token.
+type walkChild struct {
+	token string
+	value any
+}
+
+type walker struct {
+	o        options
+	pattern  *Pattern
+	yield    func(Pointer, any) bool
+	visiting map[identity.Key]struct{}
+	path     []string
+}
+
+// walk visits a node and its descendants. It returns false when the iteration is stopped.
+func (w *walker) walk(node any) bool {
+	match := w.match()
+
+	key, tracked := identity.Of(node)
+	if tracked {
+		if _, cyclic := w.visiting[key]; cyclic {
+			if !match.matched {
+				return true
+			}
+
+			return w.yield(FromTokens(w.path...), node)
+		}
+	}
+
+	children := w.descend(node, match)
+
+	if match.matched && (!w.o.leavesOnly || len(children) == 0) {
+		if !w.yield(FromTokens(w.path...), node) {
+			return false
+		}
+	}
+
+	if !match.viable || len(children) == 0 {
+		return true
+	}
+
+	if tracked {
+		w.visiting[key] = struct{}{}
+		defer delete(w.visiting, key)
+	}
+
+	for _, child := range children {
+		w.path = append(w.path, child.token)
+		ok := w.walk(child.value)
+		w.path = w.path[:len(w.path)-1]
+
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// match matches the current path against the pattern, if any. Without a pattern, all paths match.
+func (w *walker) match() patternMatch {
+	if w.pattern == nil {
+		return patternMatch{matched: true, viable: true, wildcard: true}
+	}
+
+	return w.pattern.matchPath(w.path)
+}
+
+// descend lists the children of a node to visit, pruning the branches beyond the maximum depth or
+// that cannot match the pattern.
+func (w *walker) descend(node any, match patternMatch) []walkChild {
+	if w.o.maxDepth > 0 && len(w.path) >= w.o.maxDepth {
+		return nil
+	}
+
+	switch {
+	case match.viable && !match.wildcard:
+		// only the literal tokens of the pattern may match: no need to list all children
+		return w.lookupTokens(node, match.literals)
+	case match.viable || (match.matched && w.o.leavesOnly):
+		return w.children(node)
+	default:
+		return nil
+	}
+}
+
+// children lists the child values of a node, or nil if the node is not a container.
+func (w *walker) children(node any) []walkChild {
+	if isNil(node) {
+		return nil
+	}
+
+	switch typed := node.(type) {
+	case JSONPointable:
+		return w.lookupChildren(typed)
+	case *any:
+		return w.children(*typed)
+	case map[string]any:
+		children := make([]walkChild, 0, len(typed))
+		for k, v := range typed {
+			children = append(children, walkChild{token: k, value: v})
+		}
+
+		return w.sorted(children)
+	case []any:
+		children := make([]walkChild, 0, len(typed))
+		for i, v := range typed {
+			children = append(children, walkChild{token: strconv.Itoa(i), value: v})
+		}
+
+		return children
+	}
+
+	return w.reflectChildren(reflect.Indirect(reflect.ValueOf(node)))
+}
+
+// lookupTokens resolves the children of a node for the given tokens. Tokens that fail to resolve
+// are skipped.
+func (w *walker) lookupTokens(node any, tokens []string) []walkChild {
+	children := make([]walkChild, 0, len(tokens))
+	for _, token := range tokens {
+		value, _, err := getSingleImpl(node, token, w.o)
+		if err != nil {
+			continue
+		}
+
+		children = append(children, walkChild{token: token, value: value})
+	}
+
+	return children
+}
+
+// lookupChildren lists the children of a [JSONPointable] from its underlying go type, then resolves
+// their values with JSONLookup. Tokens that fail to resolve are skipped.
+func (w *walker) lookupChildren(node JSONPointable) []walkChild {
+	children := w.reflectChildren(reflect.Indirect(reflect.ValueOf(node)))
+
+	resolved := children[:0]
+	for _, child := range children {
+		value, err := node.JSONLookup(child.token)
+		if err != nil {
+			continue
+		}
+
+		resolved = append(resolved, walkChild{token: child.token, value: value})
+	}
+
+	return resolved
+}
+
+func (w *walker) reflectChildren(rValue reflect.Value) []walkChild {
+	switch rValue.Kind() {
+	case reflect.Struct:
+		names := w.fieldNames(rValue.Type())
+		children := make([]walkChild, 0, len(names))
+		for _, name := range names {
+			fld, _, err := structField(rValue, name, w.o)
+			if err != nil {
+				// e.g. promoted field of a nil embedded pointer
+				continue
+			}
+
+			children = append(children, walkChild{token: name, value: fld.Interface()})
+		}
+
+		return children
+
+	case reflect.Map:
+		children := make([]walkChild, 0, rValue.Len())
+		entries := rValue.MapRange()
+		for entries.Next() {
+			token, ok := mapToken(entries.Key())
+			if !ok {
+				return nil
+			}
+
+			children = append(children, walkChild{token: token, value: entries.Value().Interface()})
+		}
+
+		return w.sorted(children)
+
+	case reflect.Slice, reflect.Array:
+		children := make([]walkChild, 0, rValue.Len())
+		for i := range rValue.Len() {
+			children = append(children, walkChild{token: strconv.Itoa(i), value: rValue.Index(i).Interface()})
+		}
+
+		return children
+
+	default:
+		return nil
+	}
+}
+
+// fieldNames lists the json names of the fields of a struct type, in declaration order.
+//
+// The names are listed by the [NameProvider] when it knows how to, or else guessed from the
+// exported fields of the struct and checked against the [NameProvider].
+func (w *walker) fieldNames(tpe reflect.Type) []string {
+	type namedField struct {
+		name  string
+		index []int
+	}
+
+	var candidates []string
+	if lister, ok := w.o.provider.(jsonNamesLister); ok {
+		candidates = lister.GetJSONNames(reflect.Zero(tpe).Interface())
+	} else {
+		for _, fld := range reflect.VisibleFields(tpe) {
+			if !fld.IsExported() {
+				continue
+			}
+
+			name, _, _ := strings.Cut(fld.Tag.Get("json"), ",")
+			if name == "" || name == "-" {
+				name = fld.Name
+			}
+
+			candidates = append(candidates, name)
+		}
+	}
+
+	fields := make([]namedField, 0, len(candidates))
+	for _, name := range candidates {
+		plan, ok := w.o.fields.lookup(tpe, name, w.o.provider)
+		if !ok || slices.ContainsFunc(fields, func(f namedField) bool { return slices.Equal(f.index, plan.index) }) {
+			continue
+		}
+
+		fields = append(fields, namedField{name: name, index: plan.index})
+	}
+
+	if w.o.sortKeys {
+		slices.SortFunc(fields, func(a, b namedField) int { return compareTokens(Escape(a.name), Escape(b.name)) })
+	} else {
+		slices.SortFunc(fields, func(a, b namedField) int { return slices.Compare(a.index, b.index) })
+	}
+
+	names := make([]string, 0, len(fields))
+	for _, fld := range fields {
+		names = append(names, fld.name)
+	}
+
+	return names
+}
+
+func (w *walker) sorted(children []walkChild) []walkChild {
+	if w.o.sortKeys {
+		slices.SortFunc(children, func(a, b walkChild) int { return compareTokens(Escape(a.token), Escape(b.token)) })
+	}
+
+	return children
+}
+
+// mapToken converts a map key into a token. This is the converse of [mapKey].
+func mapToken(key reflect.Value) (string, bool) {
+	kt := key.Type()
+
+	if kt == stringType {
+		return key.String(), true
+	}
+
+	if kt.Implements(textMarshalerType) {
+		if key.Kind() == reflect.Pointer && key.IsNil() {
+			return "", false
+		}
+
+		text, err := key.Interface().(encoding.TextMarshaler).MarshalText() //nolint:forcetypeassert // checked by Implements
+		if err != nil {
+			return "", false
+		}
+
+		return string(text), true
+	}
+
+	switch {
+	case kt.Kind() == reflect.String:
+		return key.String(), true
+	case isIntKind(kt.Kind()):
+		return strconv.FormatInt(key.Int(), 10), true
+	case isUintKind(kt.Kind()):
+		return strconv.FormatUint(key.Uint(), 10), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,231 @@
+// SPDX-FileCopyrightText: Copyright (c) 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonpointer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/jsonpointer/jsonname"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+// walkedPointers collects the pointers yielded by Walk, as strings.
+func walkedPointers(document any, opts ...Option) []string {
+	var pointers []string
+	for p := range Walk(document, opts...) {
+		pointers = append(pointers, p.String())
+	}
+
+	return pointers
+}
+
+func TestWalk(t *testing.T) {
+	t.Parallel()
+
+	t.Run("with JSON document", func(t *testing.T) {
+		t.Parallel()
+
+		var doc any
+		require.NoError(t, json.Unmarshal([]byte(`{"b": [1, {"c": true}], "a": "x", "a/b": null, "e": {}}`), &doc))
+
+		assert.Equal(t,
+			[]string{"", "/a", "/a~1b", "/b", "/b/0", "/b/1", "/b/1/c", "/e"},
+			walkedPointers(doc, WithSortedKeys()),
+		)
+
+		t.Run("yielded values are resolved by Get", func(t *testing.T) {
+			for p, value := range Walk(doc) {
+				got, _, err := p.Get(doc)
+				require.NoError(t, err)
+				assert.Equal(t, got, value)
+			}
+		})
+	})
+
+	t.Run("with leaves only", func(t *testing.T) {
+		t.Parallel()
+
+		doc := map[string]any{
+			"a": []any{1, 2},
+			"b": map[string]any{},
+			"c": nil,
+		}
+
+		assert.Equal(t,
+			[]string{"/a/0", "/a/1", "/b", "/c"},
+			walkedPointers(doc, WithSortedKeys(), WithLeavesOnly()),
+		)
+	})
+
+	t.Run("with max depth", func(t *testing.T) {
+		t.Parallel()
+
+		doc := map[string]any{"a": map[string]any{"b": map[string]any{"c": 1}}}
+
+		assert.Equal(t, []string{"", "/a"}, walkedPointers(doc, WithMaxDepth(1)))
+		assert.Equal(t, []string{"/a/b"}, walkedPointers(doc, WithMaxDepth(2), WithLeavesOnly()))
+		assert.Equal(t, []string{"", "/a", "/a/b", "/a/b/c"}, walkedPointers(doc, WithMaxDepth(0)))
+	})
+
+	t.Run("with sorted keys", func(t *testing.T) {
+		t.Parallel()
+
+		doc := map[int]string{10: "ten", 2: "two", 1: "one"}
+
+		assert.Equal(t, []string{"", "/1", "/2", "/10"}, walkedPointers(doc, WithSortedKeys()))
+	})
+
+	t.Run("with structs", func(t *testing.T) {
+		t.Parallel()
+
+		type Inner struct {
+			C int `json:"c"`
+		}
+		type Doc struct {
+			B       string  `json:"b"`
+			A       []Inner `json:"a"`
+			Ignored string
+			hidden  string
+		}
+
+		doc := &Doc{B: "b", A: []Inner{{C: 1}}, Ignored: "ignored", hidden: "hidden"}
+
+		t.Run("fields are visited in declaration order", func(t *testing.T) {
+			assert.Equal(t, []string{"", "/b", "/a", "/a/0", "/a/0/c"}, walkedPointers(doc))
+		})
+
+		t.Run("fields are visited in sorted order", func(t *testing.T) {
+			assert.Equal(t, []string{"", "/a", "/a/0", "/a/0/c", "/b"}, walkedPointers(doc, WithSortedKeys()))
+		})
+
+		t.Run("fields are named by the name provider", func(t *testing.T) {
+			assert.Equal(t,
+				[]string{"", "/b", "/a", "/a/0", "/a/0/c", "/Ignored"},
+				walkedPointers(doc, WithNameProvider(jsonname.NewGoNameProvider())),
+			)
+		})
+
+		t.Run("fields are checked against a name provider that does not list names", func(t *testing.T) {
+			stub := &stubNameProvider{mapping: map[string]string{"Ignored": "Ignored", "b": "B"}}
+
+			assert.Equal(t, []string{"", "/b", "/Ignored"}, walkedPointers(doc, WithNameProvider(stub)))
+		})
+
+		t.Run("fields are named by the default name provider when none is set", func(t *testing.T) {
+			assert.Equal(t, walkedPointers(doc), walkedPointers(doc, WithNameProvider(nil)))
+		})
+	})
+
+	t.Run("with promoted fields of a nil embedded pointer", func(t *testing.T) {
+		t.Parallel()
+
+		type Embedded struct {
+			Nested string
+		}
+		type Doc struct {
+			*Embedded
+
+			Field string
+		}
+
+		assert.Equal(t,
+			[]string{"", "/Embedded", "/Field"},
+			walkedPointers(Doc{}, WithNameProvider(&stubNameProvider{mapping: map[string]string{
+				"Embedded": "Embedded", "Field": "Field", "Nested": "Nested",
+			}})),
+		)
+	})
+
+	t.Run("with go arrays and map keys", func(t *testing.T) {
+		t.Parallel()
+
+		doc := map[upperKey][2]int{"A": {1, 2}}
+
+		assert.Equal(t, []string{"", "/A", "/A/0", "/A/1"}, walkedPointers(doc))
+	})
+
+	t.Run("with unsupported map keys", func(t *testing.T) {
+		t.Parallel()
+
+		doc := map[string]any{"m": map[float64]string{1.5: "x"}}
+
+		assert.Equal(t, []string{"/m"}, walkedPointers(doc, WithLeavesOnly()))
+	})
+
+	t.Run("with JSONPointable", func(t *testing.T) {
+		t.Parallel()
+
+		doc := map[string]any{"p": pointableMap{"swapped": "value", "swap": "ignored", "other": "x"}}
+
+		var leaves []string
+		for p, value := range Walk(doc, WithLeavesOnly(), WithSortedKeys()) {
+			leaves = append(leaves, p.String()+"="+value.(string))
+		}
+
+		assert.Equal(t, []string{"/p/other=x", "/p/swap=value", "/p/swapped=value"}, leaves)
+	})
+
+	t.Run("with cycles", func(t *testing.T) {
+		t.Parallel()
+
+		type node struct {
+			Name string `json:"name"`
+			Next *node  `json:"next"`
+		}
+
+		a := &node{Name: "a"}
+		b := &node{Name: "b", Next: a}
+		a.Next = b
+
+		var values []any
+		var pointers []string
+		for p, value := range Walk(a) {
+			pointers = append(pointers, p.String())
+			values = append(values, value)
+		}
+
+		assert.Equal(t, []string{"", "/name", "/next", "/next/name", "/next/next"}, pointers)
+		assert.Same(t, a, values[len(values)-1])
+
+		t.Run("shared values that do not form a cycle are visited each time", func(t *testing.T) {
+			shared := map[string]any{"x": 1}
+			doc := []any{shared, shared}
+
+			assert.Equal(t, []string{"/0/x", "/1/x"}, walkedPointers(doc, WithLeavesOnly()))
+		})
+
+		t.Run("with a map containing itself", func(t *testing.T) {
+			doc := map[string]any{}
+			doc["self"] = doc
+
+			assert.Equal(t, []string{"", "/self"}, walkedPointers(doc))
+		})
+	})
+
+	t.Run("with scalar or nil document", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []string{""}, walkedPointers(12))
+		assert.Equal(t, []string{""}, walkedPointers(nil))
+		assert.Equal(t, []string{""}, walkedPointers((*map[string]any)(nil)))
+	})
+
+	t.Run("should stop when yield returns false", func(t *testing.T) {
+		t.Parallel()
+
+		doc := []any{[]any{1, 2}, 3}
+
+		var pointers []string
+		for p := range Walk(doc) {
+			pointers = append(pointers, p.String())
+			if p.String() == "/0/0" {
+				break
+			}
+		}
+
+		assert.Equal(t, []string{"", "/0", "/0/0"}, pointers)
+	})
+}